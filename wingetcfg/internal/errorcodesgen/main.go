@@ -0,0 +1,142 @@
+// Command errorcodesgen generates winget_error_codes.go from a local copy of WinGet's returnCodes.md.
+// The markdown tables must have the Hex, Decimal, Symbol and Description columns.
+// With -check the generated code is compared with the output file and the command fails if it's stale.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type errorCode struct {
+	Hex         string
+	Decimal     int32
+	Symbol      string
+	Description string
+}
+
+func main() {
+	in := flag.String("in", "internal/errorcodesgen/returnCodes.md", "path to returnCodes.md")
+	out := flag.String("out", "winget_error_codes.go", "path to the generated Go file")
+	check := flag.Bool("check", false, "fail if the generated file is stale instead of writing it")
+	flag.Parse()
+
+	f, err := os.Open(*in)
+	if err != nil {
+		log.Fatalf("could not open return codes: %v", err)
+	}
+	defer f.Close()
+
+	codes, err := parseReturnCodes(f)
+	if err != nil {
+		log.Fatalf("could not parse %s: %v", *in, err)
+	}
+
+	src, err := generate(codes)
+	if err != nil {
+		log.Fatalf("could not generate code: %v", err)
+	}
+
+	if *check {
+		current, err := os.ReadFile(*out)
+		if err != nil {
+			log.Fatalf("could not read %s: %v", *out, err)
+		}
+		if !bytes.Equal(current, src) {
+			log.Fatalf("%s is stale, run go generate", *out)
+		}
+		return
+	}
+
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatalf("could not write %s: %v", *out, err)
+	}
+}
+
+// parseReturnCodes reads the rows of the markdown tables, header and separator rows are skipped
+func parseReturnCodes(r io.Reader) ([]errorCode, error) {
+	codes := []errorCode{}
+	seen := map[string]bool{}
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(text, "|") {
+			continue
+		}
+
+		cells := strings.Split(strings.Trim(text, "|"), "|")
+		for i := range cells {
+			cells[i] = strings.TrimSpace(cells[i])
+		}
+		if len(cells) != 4 {
+			return nil, fmt.Errorf("line %d: expected 4 columns, found %d", line, len(cells))
+		}
+		if strings.EqualFold(cells[0], "hex") || strings.Trim(cells[0], "-: ") == "" {
+			continue
+		}
+
+		hex := strings.ToUpper(strings.TrimPrefix(strings.ToLower(cells[0]), "0x"))
+		code, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s is not a valid hexadecimal code", line, cells[0])
+		}
+
+		decimal, err := strconv.ParseInt(cells[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s is not a valid decimal code", line, cells[1])
+		}
+		if int32(uint32(code)) != int32(decimal) {
+			return nil, fmt.Errorf("line %d: decimal code %s doesn't match %s", line, cells[1], cells[0])
+		}
+
+		c := errorCode{
+			Hex:         "0x" + hex,
+			Decimal:     int32(decimal),
+			Symbol:      cells[2],
+			Description: cells[3],
+		}
+		if seen[c.Hex] {
+			return nil, fmt.Errorf("line %d: duplicate code %s", line, c.Hex)
+		}
+		seen[c.Hex] = true
+
+		codes = append(codes, c)
+	}
+
+	return codes, scanner.Err()
+}
+
+func generate(codes []errorCode) ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteString("// Code generated by errorcodesgen from internal/errorcodesgen/returnCodes.md; DO NOT EDIT.\n\n")
+	b.WriteString("package wingetcfg\n\n")
+
+	b.WriteString("// ErrorCodes for WinGet\n")
+	b.WriteString("// Reference: https://github.com/microsoft/winget-cli/blob/master/doc/windows/package-manager/winget/returnCodes.md\n")
+	b.WriteString("var ErrorCodes = map[string]string{\n")
+	for _, c := range codes {
+		fmt.Fprintf(&b, "%q: %q,\n", c.Hex, c.Description)
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("// ErrorCodeTable contains the WinGet error codes with their decimal values and symbolic names\n")
+	b.WriteString("var ErrorCodeTable = []ErrorCodeInfo{\n")
+	for _, c := range codes {
+		fmt.Fprintf(&b, "{Hex: %q, Decimal: %d, Symbol: %q, Description: %q},\n", c.Hex, c.Decimal, c.Symbol, c.Description)
+	}
+	b.WriteString("}\n")
+
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// TestGeneratedFileIsUpToDate fails when winget_error_codes.go doesn't match returnCodes.md,
+// run go generate in the wingetcfg package to update it
+func TestGeneratedFileIsUpToDate(t *testing.T) {
+	f, err := os.Open("returnCodes.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	codes, err := parseReturnCodes(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := generate(codes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current, err := os.ReadFile("../../winget_error_codes.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(current, src) {
+		t.Fatal("winget_error_codes.go is stale, run go generate in the wingetcfg package")
+	}
+}
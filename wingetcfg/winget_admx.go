@@ -0,0 +1,715 @@
+package wingetcfg
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type PolicyClass string
+
+const (
+	PolicyClassMachine PolicyClass = "Machine"
+	PolicyClassUser    PolicyClass = "User"
+	PolicyClassBoth    PolicyClass = "Both"
+)
+
+type PolicyState string
+
+const (
+	PolicyEnabled  PolicyState = "Enabled"
+	PolicyDisabled PolicyState = "Disabled"
+)
+
+const (
+	PolicyElementBoolean     = "boolean"
+	PolicyElementDecimal     = "decimal"
+	PolicyElementLongDecimal = "longDecimal"
+	PolicyElementText        = "text"
+	PolicyElementMultiText   = "multiText"
+	PolicyElementEnum        = "enum"
+	PolicyElementList        = "list"
+)
+
+// PolicyCatalog contains the policy definitions loaded from ADMX files and their ADML resources
+type PolicyCatalog struct {
+	policies map[string]*PolicyDefinition
+}
+
+// PolicyDefinition describes a policy found in an ADMX file, display names and explanations
+// are resolved using the ADML file loaded with the ADMX file.
+type PolicyDefinition struct {
+	Name        string
+	DisplayName string
+	Explain     string
+	Class       PolicyClass
+	Key         string
+	ValueName   string
+	Elements    []PolicyElement
+
+	policy  *admxPolicy
+	strings map[string]string
+}
+
+// PolicyElement describes a value that can be set when a policy is enabled.
+// Options contains the display names that can be chosen for an enum element.
+type PolicyElement struct {
+	ID       string
+	Type     string
+	Required bool
+	Options  []string
+}
+
+type admxPolicyDefinitions struct {
+	Policies []admxPolicy `xml:"policies>policy"`
+}
+
+type admxPolicy struct {
+	Name          string        `xml:"name,attr"`
+	Class         PolicyClass   `xml:"class,attr"`
+	DisplayName   string        `xml:"displayName,attr"`
+	ExplainText   string        `xml:"explainText,attr"`
+	Key           string        `xml:"key,attr"`
+	ValueName     string        `xml:"valueName,attr"`
+	EnabledValue  *admxValue    `xml:"enabledValue"`
+	DisabledValue *admxValue    `xml:"disabledValue"`
+	EnabledList   *admxList     `xml:"enabledList"`
+	DisabledList  *admxList     `xml:"disabledList"`
+	Elements      *admxElements `xml:"elements"`
+}
+
+type admxValue struct {
+	Decimal     *admxNumber `xml:"decimal"`
+	LongDecimal *admxNumber `xml:"longDecimal"`
+	String      *string     `xml:"string"`
+	Delete      *struct{}   `xml:"delete"`
+}
+
+type admxNumber struct {
+	Value string `xml:"value,attr"`
+}
+
+type admxList struct {
+	DefaultKey string         `xml:"defaultKey,attr"`
+	Items      []admxListItem `xml:"item"`
+}
+
+type admxListItem struct {
+	Key       string    `xml:"key,attr"`
+	ValueName string    `xml:"valueName,attr"`
+	Value     admxValue `xml:"value"`
+}
+
+type admxElements struct {
+	Booleans     []admxBoolean   `xml:"boolean"`
+	Decimals     []admxDecimal   `xml:"decimal"`
+	LongDecimals []admxDecimal   `xml:"longDecimal"`
+	Texts        []admxText      `xml:"text"`
+	MultiTexts   []admxText      `xml:"multiText"`
+	Enums        []admxEnum      `xml:"enum"`
+	Lists        []admxListValue `xml:"list"`
+}
+
+type admxElement struct {
+	ID        string `xml:"id,attr"`
+	Key       string `xml:"key,attr"`
+	ValueName string `xml:"valueName,attr"`
+	Required  bool   `xml:"required,attr"`
+}
+
+type admxBoolean struct {
+	admxElement
+	TrueValue  *admxValue `xml:"trueValue"`
+	FalseValue *admxValue `xml:"falseValue"`
+	TrueList   *admxList  `xml:"trueList"`
+	FalseList  *admxList  `xml:"falseList"`
+}
+
+type admxDecimal struct {
+	admxElement
+	MinValue    *uint64 `xml:"minValue,attr"`
+	MaxValue    *uint64 `xml:"maxValue,attr"`
+	StoreAsText bool    `xml:"storeAsText,attr"`
+}
+
+type admxText struct {
+	admxElement
+	MaxLength  int  `xml:"maxLength,attr"`
+	Expandable bool `xml:"expandable,attr"`
+}
+
+type admxEnum struct {
+	admxElement
+	Items []admxEnumItem `xml:"item"`
+}
+
+type admxEnumItem struct {
+	DisplayName string    `xml:"displayName,attr"`
+	Value       admxValue `xml:"value"`
+	ValueList   *admxList `xml:"valueList"`
+}
+
+type admxListValue struct {
+	admxElement
+	ValuePrefix   *string `xml:"valuePrefix,attr"`
+	Additive      bool    `xml:"additive,attr"`
+	ExplicitValue bool    `xml:"explicitValue,attr"`
+	Expandable    bool    `xml:"expandable,attr"`
+}
+
+type admlPolicyDefinitionResources struct {
+	Strings []admlString `xml:"resources>stringTable>string"`
+}
+
+type admlString struct {
+	ID    string `xml:"id,attr"`
+	Value string `xml:",chardata"`
+}
+
+func NewPolicyCatalog() *PolicyCatalog {
+	return &PolicyCatalog{policies: map[string]*PolicyDefinition{}}
+}
+
+// LoadADMXFile loads the policy definitions of an ADMX file, admlPath is the ADML file
+// for the language used to resolve display names, e.g en-US\ControlPanel.adml
+func (c *PolicyCatalog) LoadADMXFile(admxPath string, admlPath string) error {
+	admx, err := os.Open(admxPath)
+	if err != nil {
+		return err
+	}
+	defer admx.Close()
+
+	adml, err := os.Open(admlPath)
+	if err != nil {
+		return err
+	}
+	defer adml.Close()
+
+	return c.LoadADMX(admx, adml)
+}
+
+// LoadADMX loads the policy definitions of an ADMX document and resolves its strings using the ADML document.
+// Policies already in the catalog with the same name are replaced.
+func (c *PolicyCatalog) LoadADMX(admx io.Reader, adml io.Reader) error {
+	definitions := admxPolicyDefinitions{}
+	if err := xml.NewDecoder(admx).Decode(&definitions); err != nil {
+		return fmt.Errorf("could not parse ADMX file: %v", err)
+	}
+
+	resources := admlPolicyDefinitionResources{}
+	if err := xml.NewDecoder(adml).Decode(&resources); err != nil {
+		return fmt.Errorf("could not parse ADML file: %v", err)
+	}
+
+	strs := map[string]string{}
+	for _, s := range resources.Strings {
+		strs[s.ID] = strings.TrimSpace(s.Value)
+	}
+
+	for i := range definitions.Policies {
+		p := &definitions.Policies[i]
+		if p.Name == "" {
+			return errors.New("ADMX file contains a policy without name")
+		}
+
+		d := PolicyDefinition{
+			Name:        p.Name,
+			DisplayName: resolveADMLString(strs, p.DisplayName),
+			Explain:     resolveADMLString(strs, p.ExplainText),
+			Class:       p.Class,
+			Key:         p.Key,
+			ValueName:   p.ValueName,
+			policy:      p,
+			strings:     strs,
+		}
+
+		if e := p.Elements; e != nil {
+			for _, b := range e.Booleans {
+				d.Elements = append(d.Elements, PolicyElement{ID: b.ID, Type: PolicyElementBoolean, Required: b.Required})
+			}
+			for _, n := range e.Decimals {
+				d.Elements = append(d.Elements, PolicyElement{ID: n.ID, Type: PolicyElementDecimal, Required: n.Required})
+			}
+			for _, n := range e.LongDecimals {
+				d.Elements = append(d.Elements, PolicyElement{ID: n.ID, Type: PolicyElementLongDecimal, Required: n.Required})
+			}
+			for _, t := range e.Texts {
+				d.Elements = append(d.Elements, PolicyElement{ID: t.ID, Type: PolicyElementText, Required: t.Required})
+			}
+			for _, t := range e.MultiTexts {
+				d.Elements = append(d.Elements, PolicyElement{ID: t.ID, Type: PolicyElementMultiText, Required: t.Required})
+			}
+			for _, en := range e.Enums {
+				options := []string{}
+				for _, item := range en.Items {
+					options = append(options, resolveADMLString(strs, item.DisplayName))
+				}
+				d.Elements = append(d.Elements, PolicyElement{ID: en.ID, Type: PolicyElementEnum, Required: en.Required, Options: options})
+			}
+			for _, l := range e.Lists {
+				d.Elements = append(d.Elements, PolicyElement{ID: l.ID, Type: PolicyElementList, Required: l.Required})
+			}
+		}
+
+		c.policies[strings.ToLower(p.Name)] = &d
+	}
+
+	return nil
+}
+
+// Policies returns the policy definitions in the catalog sorted by name
+func (c *PolicyCatalog) Policies() []*PolicyDefinition {
+	policies := make([]*PolicyDefinition, 0, len(c.policies))
+	for _, p := range c.policies {
+		policies = append(policies, p)
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+	return policies
+}
+
+// Policy finds a policy by its name or by its display name, e.g. DisableWindowsConsumerFeatures
+// or "Turn off Microsoft consumer experiences". The search is case insensitive.
+func (c *PolicyCatalog) Policy(name string) (*PolicyDefinition, bool) {
+	if p, ok := c.policies[strings.ToLower(name)]; ok {
+		return p, true
+	}
+	for _, p := range c.policies {
+		if strings.EqualFold(p.DisplayName, name) {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// PolicyResources creates the xRegistry resources that set a policy to the chosen state.
+// Class selects the hive, HKEY_LOCAL_MACHINE for Machine and HKEY_CURRENT_USER for User, it can be empty
+// if the policy only applies to one class.
+// Values contains the data for the policy elements keyed by element ID and it's only used when the policy is enabled:
+// boolean elements expect a bool, decimal elements an integer or a numeric string, text elements a string,
+// multiText elements a []string, enum elements the display name of the chosen item or its index and
+// list elements a []string or, for lists with explicit value names, a map[string]string.
+func (c *PolicyCatalog) PolicyResources(name string, class PolicyClass, state PolicyState, values map[string]any) ([]*WinGetResource, error) {
+	d, ok := c.Policy(name)
+	if !ok {
+		return nil, fmt.Errorf("policy %s not found", name)
+	}
+
+	if class == "" {
+		class = d.Class
+	}
+	if class != PolicyClassMachine && class != PolicyClassUser {
+		return nil, fmt.Errorf("policy %s: a Machine or User class must be chosen", d.Name)
+	}
+	if d.Class != PolicyClassBoth && d.Class != class {
+		return nil, fmt.Errorf("policy %s: the policy doesn't apply to the %s class", d.Name, class)
+	}
+
+	hive := RegistryHiveLocalMachine
+	if class == PolicyClassUser {
+		hive = RegistryHiveCurrentUser
+	}
+
+	b := policyResourcesBuilder{
+		hive:        hive,
+		key:         d.Key,
+		description: fmt.Sprintf("%s: %s", d.DisplayName, state),
+	}
+	if d.DisplayName == "" {
+		b.description = fmt.Sprintf("%s: %s", d.Name, state)
+	}
+
+	p := d.policy
+	switch state {
+	case PolicyEnabled:
+		if err := b.addValue(d.Key, d.ValueName, p.EnabledValue, 1); err != nil {
+			return nil, err
+		}
+		if err := b.addList(p.EnabledList); err != nil {
+			return nil, err
+		}
+		if p.Elements != nil {
+			if err := b.addElements(d, values); err != nil {
+				return nil, err
+			}
+		}
+	case PolicyDisabled:
+		if err := b.addValue(d.Key, d.ValueName, p.DisabledValue, 0); err != nil {
+			return nil, err
+		}
+		if err := b.addList(p.DisabledList); err != nil {
+			return nil, err
+		}
+		if p.Elements != nil {
+			if err := b.removeElements(p.Elements); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("policy %s: state %s is not valid", d.Name, state)
+	}
+
+	if err := b.addClearedKeys(); err != nil {
+		return nil, err
+	}
+
+	return b.resources, nil
+}
+
+type policyResourcesBuilder struct {
+	hive        RegistryHive
+	key         string
+	description string
+	resources   []*WinGetResource
+	clearedKeys []string
+}
+
+func (b *policyResourcesBuilder) path(key string) string {
+	if key == "" {
+		key = b.key
+	}
+	return RegistryPath{Hive: b.hive, SubKey: key}.String()
+}
+
+func (b *policyResourcesBuilder) add(r *WinGetResource, err error) error {
+	if err != nil {
+		return err
+	}
+	b.resources = append(b.resources, r)
+	return nil
+}
+
+// addValue sets a registry value as defined by an ADMX value element, if the element is not defined
+// the value is set as a DWord with the default data as Group Policy does
+func (b *policyResourcesBuilder) addValue(key string, valueName string, v *admxValue, defaultData uint32) error {
+	if valueName == "" {
+		return nil
+	}
+
+	if v == nil {
+		return b.add(NewWinGetRegistryResource("", b.description, b.path(key), valueName, RegistryValueTypeDWord, strconv.FormatUint(uint64(defaultData), 10), EnsurePresent, false, true))
+	}
+
+	switch {
+	case v.Delete != nil:
+		return b.add(RemoveRegistryValue("", b.description, b.path(key), valueName))
+	case v.Decimal != nil:
+		return b.add(NewWinGetRegistryResource("", b.description, b.path(key), valueName, RegistryValueTypeDWord, v.Decimal.Value, EnsurePresent, false, true))
+	case v.LongDecimal != nil:
+		return b.add(NewWinGetRegistryResource("", b.description, b.path(key), valueName, RegistryValueTypeQWord, v.LongDecimal.Value, EnsurePresent, false, true))
+	case v.String != nil:
+		return b.add(NewWinGetRegistryResource("", b.description, b.path(key), valueName, RegistryValueTypeString, *v.String, EnsurePresent, false, true))
+	}
+
+	return fmt.Errorf("the value for %s\\%s is not valid", key, valueName)
+}
+
+func (b *policyResourcesBuilder) addList(l *admxList) error {
+	if l == nil {
+		return nil
+	}
+
+	for _, item := range l.Items {
+		key := item.Key
+		if key == "" {
+			key = l.DefaultKey
+		}
+		if err := b.addValue(key, item.ValueName, &item.Value, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clearKey removes the values of a key as the **delvals. directive does. The key is never deleted
+// as the policy may write other values into it, the values are removed by a script resource added
+// by addClearedKeys once all the values written by the policy are known.
+func (b *policyResourcesBuilder) clearKey(key string) {
+	path := b.path(key)
+	for _, cleared := range b.clearedKeys {
+		if cleared == path {
+			return
+		}
+	}
+	b.clearedKeys = append(b.clearedKeys, path)
+}
+
+// addClearedKeys adds a script resource for each cleared key that removes the values that the
+// policy doesn't write into it, see clearRegistryKeyResource
+func (b *policyResourcesBuilder) addClearedKeys() error {
+	for _, key := range b.clearedKeys {
+		if err := b.add(clearRegistryKeyResource(b.description, key, b.resources)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *policyResourcesBuilder) addElements(d *PolicyDefinition, values map[string]any) error {
+	e := d.policy.Elements
+
+	value := func(element admxElement) (any, bool, error) {
+		v, ok := values[element.ID]
+		if !ok && element.Required {
+			return nil, false, fmt.Errorf("policy %s: element %s is required", d.Name, element.ID)
+		}
+		return v, ok, nil
+	}
+
+	for _, el := range e.Booleans {
+		v, ok, err := value(el.admxElement)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		enabled, isBool := v.(bool)
+		if !isBool {
+			return fmt.Errorf("policy %s: element %s expects a boolean", d.Name, el.ID)
+		}
+
+		if enabled {
+			err = b.addValue(el.Key, el.ValueName, el.TrueValue, 1)
+			if err == nil {
+				err = b.addList(el.TrueList)
+			}
+		} else {
+			err = b.addValue(el.Key, el.ValueName, el.FalseValue, 0)
+			if err == nil {
+				err = b.addList(el.FalseList)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	decimals := [][]admxDecimal{e.Decimals, e.LongDecimals}
+	for i, list := range decimals {
+		valueType, bitSize := RegistryValueTypeDWord, 32
+		if i == 1 {
+			valueType, bitSize = RegistryValueTypeQWord, 64
+		}
+
+		for _, el := range list {
+			v, ok, err := value(el.admxElement)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+
+			n, err := strconv.ParseUint(fmt.Sprint(v), 10, bitSize)
+			if err != nil {
+				return fmt.Errorf("policy %s: element %s expects a positive number", d.Name, el.ID)
+			}
+
+			min, max := uint64(0), uint64(9999)
+			if el.MinValue != nil {
+				min = *el.MinValue
+			}
+			if el.MaxValue != nil {
+				max = *el.MaxValue
+			}
+			if n < min || n > max {
+				return fmt.Errorf("policy %s: element %s must be between %d and %d", d.Name, el.ID, min, max)
+			}
+
+			dataType := valueType
+			if el.StoreAsText {
+				dataType = RegistryValueTypeString
+			}
+			if err := b.add(NewWinGetRegistryResource("", b.description, b.path(el.Key), el.ValueName, dataType, strconv.FormatUint(n, 10), EnsurePresent, false, true)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, el := range e.Texts {
+		v, ok, err := value(el.admxElement)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		text, isString := v.(string)
+		if !isString {
+			return fmt.Errorf("policy %s: element %s expects a string", d.Name, el.ID)
+		}
+		if el.MaxLength > 0 && len(text) > el.MaxLength {
+			return fmt.Errorf("policy %s: element %s can't be longer than %d characters", d.Name, el.ID, el.MaxLength)
+		}
+
+		valueType := RegistryValueTypeString
+		if el.Expandable {
+			valueType = RegistryValueTypeExpandString
+		}
+		if err := b.add(NewWinGetRegistryResource("", b.description, b.path(el.Key), el.ValueName, valueType, text, EnsurePresent, false, true)); err != nil {
+			return err
+		}
+	}
+
+	for _, el := range e.MultiTexts {
+		v, ok, err := value(el.admxElement)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		lines, isList := v.([]string)
+		if !isList {
+			return fmt.Errorf("policy %s: element %s expects a list of strings", d.Name, el.ID)
+		}
+		if err := b.add(AddRegistryMultiStringValue("", b.description, b.path(el.Key), el.ValueName, lines, true)); err != nil {
+			return err
+		}
+	}
+
+	for _, el := range e.Enums {
+		v, ok, err := value(el.admxElement)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		index := -1
+		switch choice := v.(type) {
+		case int:
+			index = choice
+		case string:
+			for i, item := range el.Items {
+				if strings.EqualFold(resolveADMLString(d.strings, item.DisplayName), choice) {
+					index = i
+				}
+			}
+		}
+		if index < 0 || index >= len(el.Items) {
+			return fmt.Errorf("policy %s: %v is not a valid option for element %s", d.Name, v, el.ID)
+		}
+
+		item := el.Items[index]
+		if err := b.addValue(el.Key, el.ValueName, &item.Value, 0); err != nil {
+			return err
+		}
+		if err := b.addList(item.ValueList); err != nil {
+			return err
+		}
+	}
+
+	for _, el := range e.Lists {
+		v, ok, err := value(el.admxElement)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		valueType := RegistryValueTypeString
+		if el.Expandable {
+			valueType = RegistryValueTypeExpandString
+		}
+
+		if !el.Additive {
+			b.clearKey(el.Key)
+		}
+
+		entries := [][2]string{}
+		switch items := v.(type) {
+		case []string:
+			if el.ExplicitValue {
+				return fmt.Errorf("policy %s: element %s expects a map of value names and data", d.Name, el.ID)
+			}
+			for i, item := range items {
+				// Without valuePrefix the data is also the value name, with an empty prefix the values are numbered from 1
+				name := item
+				if el.ValuePrefix != nil {
+					name = *el.ValuePrefix + strconv.Itoa(i+1)
+				}
+				entries = append(entries, [2]string{name, item})
+			}
+		case map[string]string:
+			if !el.ExplicitValue {
+				return fmt.Errorf("policy %s: element %s expects a list of strings", d.Name, el.ID)
+			}
+			names := make([]string, 0, len(items))
+			for name := range items {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				entries = append(entries, [2]string{name, items[name]})
+			}
+		default:
+			return fmt.Errorf("policy %s: element %s expects a list", d.Name, el.ID)
+		}
+
+		for _, entry := range entries {
+			if err := b.add(NewWinGetRegistryResource("", b.description, b.path(el.Key), entry[0], valueType, entry[1], EnsurePresent, false, true)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// removeElements removes the values set by the policy elements, as Group Policy does when a policy is disabled
+func (b *policyResourcesBuilder) removeElements(e *admxElements) error {
+	elements := []admxElement{}
+	for _, el := range e.Booleans {
+		elements = append(elements, el.admxElement)
+	}
+	for _, el := range e.Decimals {
+		elements = append(elements, el.admxElement)
+	}
+	for _, el := range e.LongDecimals {
+		elements = append(elements, el.admxElement)
+	}
+	for _, el := range e.Texts {
+		elements = append(elements, el.admxElement)
+	}
+	for _, el := range e.MultiTexts {
+		elements = append(elements, el.admxElement)
+	}
+	for _, el := range e.Enums {
+		elements = append(elements, el.admxElement)
+	}
+
+	for _, el := range elements {
+		if el.ValueName == "" {
+			continue
+		}
+		if err := b.add(RemoveRegistryValue("", b.description, b.path(el.Key), el.ValueName)); err != nil {
+			return err
+		}
+	}
+
+	for _, el := range e.Lists {
+		b.clearKey(el.Key)
+	}
+
+	return nil
+}
+
+// resolveADMLString resolves $(string.ID) references using the ADML string table
+func resolveADMLString(strs map[string]string, ref string) string {
+	if strings.HasPrefix(ref, "$(string.") && strings.HasSuffix(ref, ")") {
+		if s, ok := strs[strings.TrimSuffix(strings.TrimPrefix(ref, "$(string."), ")")]; ok {
+			return s
+		}
+	}
+	return ref
+}
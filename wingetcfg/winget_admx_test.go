@@ -0,0 +1,175 @@
+package wingetcfg
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func loadTestPolicyCatalog(t *testing.T) *PolicyCatalog {
+	t.Helper()
+	c := NewPolicyCatalog()
+	if err := c.LoadADMXFile(filepath.Join("testdata", "admx", "contoso.admx"), filepath.Join("testdata", "admx", "en-US", "contoso.adml")); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// registryValues returns the data of the Present xRegistry resources keyed by key\valueName
+// and the Absent ones with an empty string
+func registryValues(resources []*WinGetResource) map[string]string {
+	values := map[string]string{}
+	for _, r := range resources {
+		if r.Resource != WinGetRegistryResource {
+			continue
+		}
+		name := settingString(r.Settings, "Key") + `\` + settingString(r.Settings, "ValueName")
+		values[name] = settingString(r.Settings, "ValueData")
+	}
+	return values
+}
+
+func TestLoadADMX(t *testing.T) {
+	c := loadTestPolicyCatalog(t)
+
+	if n := len(c.Policies()); n != 6 {
+		t.Fatalf("got %d policies, want 6", n)
+	}
+
+	d, ok := c.Policy("choose the update channel")
+	if !ok {
+		t.Fatal("policies should be found by their display name")
+	}
+	if d.Name != "UpdateChannel" || d.Explain != "Sets the channel used to get updates." || d.Class != PolicyClassMachine {
+		t.Errorf("unexpected definition %+v", d)
+	}
+	if len(d.Elements) != 1 || d.Elements[0].Type != PolicyElementEnum || strings.Join(d.Elements[0].Options, ",") != "Stable,Beta" {
+		t.Errorf("unexpected elements %+v", d.Elements)
+	}
+}
+
+func TestPolicyResourcesEnabledAndDisabled(t *testing.T) {
+	c := loadTestPolicyCatalog(t)
+	const value = `HKEY_LOCAL_MACHINE\Software\Policies\Contoso\TelemetryEnabled`
+
+	for state, want := range map[PolicyState]string{PolicyEnabled: "1", PolicyDisabled: "0"} {
+		resources, err := c.PolicyResources("TelemetryEnabled", "", state, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resources) != 1 {
+			t.Fatalf("%s: got %d resources, want 1", state, len(resources))
+		}
+		r := resources[0]
+		if settingString(r.Settings, "ValueType") != RegistryValueTypeDWord || registryValues(resources)[value] != want {
+			t.Errorf("%s: unexpected settings %v", state, r.Settings)
+		}
+		if r.Directives.Description != "Allow sending diagnostic data: "+string(state) {
+			t.Errorf("%s: unexpected description %s", state, r.Directives.Description)
+		}
+	}
+}
+
+func TestPolicyResourcesDecimalRange(t *testing.T) {
+	c := loadTestPolicyCatalog(t)
+
+	resources, err := c.PolicyResources("CacheSize", PolicyClassUser, PolicyEnabled, map[string]any{"CacheSizeMB": 512})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := registryValues(resources)[`HKEY_CURRENT_USER\Software\Policies\Contoso\CacheSize`]; got != "512" {
+		t.Errorf("got CacheSize %q, want 512", got)
+	}
+
+	for _, values := range []map[string]any{{"CacheSizeMB": 8}, {"CacheSizeMB": 2048}, {"CacheSizeMB": "large"}, {}} {
+		if _, err := c.PolicyResources("CacheSize", PolicyClassMachine, PolicyEnabled, values); err == nil {
+			t.Errorf("%v: expected an error", values)
+		}
+	}
+}
+
+func TestPolicyResourcesEnum(t *testing.T) {
+	c := loadTestPolicyCatalog(t)
+
+	resources, err := c.PolicyResources("UpdateChannel", "", PolicyEnabled, map[string]any{"Channel": "beta"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := registryValues(resources)
+	const key = `HKEY_LOCAL_MACHINE\Software\Policies\Contoso\Update\`
+	if values[key+"UpdateChannelEnabled"] != "1" || values[key+"Channel"] != "beta" || values[key+"AllowPrerelease"] != "1" {
+		t.Errorf("unexpected values %v", values)
+	}
+
+	resources, err = c.PolicyResources("UpdateChannel", "", PolicyEnabled, map[string]any{"Channel": 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values := registryValues(resources); values[key+"Channel"] != "stable" || len(values) != 2 {
+		t.Errorf("unexpected values %v", values)
+	}
+
+	if _, err := c.PolicyResources("UpdateChannel", "", PolicyEnabled, map[string]any{"Channel": "Nightly"}); err == nil {
+		t.Error("an option that is not in the enum should fail")
+	}
+}
+
+func TestPolicyResourcesListWithEmptyPrefix(t *testing.T) {
+	c := loadTestPolicyCatalog(t)
+	const key = `HKEY_LOCAL_MACHINE\Software\Policies\Contoso\URLBlocklist`
+
+	resources, err := c.PolicyResources("URLBlocklist", PolicyClassMachine, PolicyEnabled, map[string]any{"URLBlocklistDesc": []string{"x", "y"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := registryValues(resources)
+	if len(values) != 2 || values[key+`\1`] != "x" || values[key+`\2`] != "y" {
+		t.Errorf("an empty valuePrefix should number the values, got %v", values)
+	}
+
+	clear := resources[len(resources)-1]
+	if clear.Resource != PSDscScriptResource || !strings.Contains(settingString(clear.Settings, "SetScript"), "$keep = @('(default)', '1', '2')") {
+		t.Errorf("a non additive list should clear the other values of its key, got %v", clear.Settings)
+	}
+
+	resources, err = c.PolicyResources("URLBlocklist", PolicyClassMachine, PolicyDisabled, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) != 1 || resources[0].Resource != PSDscScriptResource || !strings.Contains(settingString(resources[0].Settings, "SetScript"), "$keep = @('(default)')\n") {
+		t.Errorf("disabling the policy should clear the list, got %v", resources)
+	}
+}
+
+func TestPolicyResourcesAdditiveListWithoutPrefix(t *testing.T) {
+	c := loadTestPolicyCatalog(t)
+	const key = `HKEY_LOCAL_MACHINE\Software\Policies\Contoso\TrustedSites`
+
+	resources, err := c.PolicyResources("TrustedSites", "", PolicyEnabled, map[string]any{"TrustedSitesList": []string{"https://contoso.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) != 1 || registryValues(resources)[key+`\https://contoso.com`] != "https://contoso.com" {
+		t.Errorf("without valuePrefix the data should be the value name, got %v", registryValues(resources))
+	}
+}
+
+func TestPolicyResourcesClass(t *testing.T) {
+	c := loadTestPolicyCatalog(t)
+	values := map[string]any{"WallpaperPath": `%USERPROFILE%\wallpaper.jpg`}
+
+	if _, err := c.PolicyResources("Wallpaper", PolicyClassMachine, PolicyEnabled, values); err == nil {
+		t.Error("a User policy can't be applied to the Machine class")
+	}
+	if _, err := c.PolicyResources("CacheSize", "", PolicyEnabled, map[string]any{"CacheSizeMB": 64}); err == nil {
+		t.Error("a class must be chosen for policies that apply to both classes")
+	}
+
+	resources, err := c.PolicyResources("Wallpaper", "", PolicyEnabled, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) != 1 || settingString(resources[0].Settings, "Key") != `HKEY_CURRENT_USER\Software\Policies\Contoso\Desktop` || settingString(resources[0].Settings, "ValueType") != RegistryValueTypeExpandString {
+		t.Errorf("unexpected resources %v", resources[0].Settings)
+	}
+}
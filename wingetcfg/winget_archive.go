@@ -0,0 +1,85 @@
+package wingetcfg
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	WinGetArchiveResource = "xPSDesiredStateConfiguration/xArchive"
+)
+
+// ExpandArchive expands a ZIP file, e.g. a portable tool downloaded with DownloadFile.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// Path is required and is the path to the ZIP file.
+// Destination is required and is the directory where the archive is expanded.
+func ExpandArchive(ID string, description string, path string, destination string) (*WinGetResource, error) {
+	return NewArchiveResource(ID, description, path, destination, false, "", true, EnsurePresent)
+}
+
+// RemoveArchive removes the files expanded from a ZIP file, the other files in the destination are kept.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// Path is required and is the path to the ZIP file.
+// Destination is required and is the directory where the archive was expanded.
+func RemoveArchive(ID string, description string, path string, destination string) (*WinGetResource, error) {
+	return NewArchiveResource(ID, description, path, destination, false, "", false, EnsureAbsent)
+}
+
+// NewArchiveResource creates a new WinGetResource that contains the settings to expand a ZIP file.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// Path is required and is the path to the ZIP file.
+// Destination is required and is the directory where the archive is expanded.
+// Validate specifies whether the expanded files are compared with the archive using the checksum.
+// Checksum is FileHashSHA1, FileHashSHA256, FileHashSHA512, FileChecksumCreatedDate or FileChecksumModifiedDate,
+// it requires Validate. If Validate is true and Checksum is empty the resource's default, SHA-256, is used.
+// Force overwrites the files that don't match the archive.
+// Ensure specifies whether the expanded files should exist.
+// Reference: https://github.com/dsccommunity/xPSDesiredStateConfiguration/blob/main/source/DSCResources/DSC_xArchive/DSC_xArchive.psm1
+func NewArchiveResource(ID string, description string, path string, destination string, validate bool, checksum string, force bool, ensure string) (*WinGetResource, error) {
+	r := WinGetResource{}
+	r.Resource = WinGetArchiveResource
+
+	// ID (optional)
+	if ID != "" {
+		r.ID = ID
+	}
+
+	// Directives
+	r.Directives.Description = description
+	r.Directives.AllowPreRelease = true
+
+	// Settings
+	r.Settings = map[string]any{}
+
+	if path == "" {
+		return nil, errors.New("path cannot be empty")
+	}
+	r.Settings["Path"] = path
+
+	if destination == "" {
+		return nil, errors.New("destination cannot be empty")
+	}
+	r.Settings["Destination"] = destination
+
+	r.Settings["Validate"] = validate
+
+	if checksum != "" {
+		name, ok := fileChecksums[checksum]
+		if !ok {
+			return nil, fmt.Errorf("checksum %s is not valid, use SHA1, SHA256, SHA512, CreatedDate or ModifiedDate", checksum)
+		}
+		if !validate {
+			return nil, errors.New("checksum requires validate to be true")
+		}
+		r.Settings["Checksum"] = name
+	}
+
+	r.Settings["Force"] = force
+
+	r.Settings["Ensure"] = SetEnsureValue(ensure)
+
+	return &r, nil
+}
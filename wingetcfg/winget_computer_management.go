@@ -0,0 +1,278 @@
+package wingetcfg
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	WinGetTimeZoneResource                  = "ComputerManagementDsc/TimeZone"
+	WinGetPowerPlanResource                 = "ComputerManagementDsc/PowerPlan"
+	WinGetPowerShellExecutionPolicyResource = "ComputerManagementDsc/PowerShellExecutionPolicy"
+	WinGetRemoteDesktopAdminResource        = "ComputerManagementDsc/RemoteDesktopAdmin"
+	WinGetComputerResource                  = "ComputerManagementDsc/Computer"
+	WinGetVirtualMemoryResource             = "ComputerManagementDsc/VirtualMemory"
+)
+
+const (
+	PowerPlanBalanced            string = "Balanced"
+	PowerPlanHighPerformance     string = "High performance"
+	PowerPlanPowerSaver          string = "Power saver"
+	PowerPlanUltimatePerformance string = "Ultimate Performance"
+)
+
+const (
+	ExecutionPolicyAllSigned    string = "AllSigned"
+	ExecutionPolicyBypass       string = "Bypass"
+	ExecutionPolicyDefault      string = "Default"
+	ExecutionPolicyRemoteSigned string = "RemoteSigned"
+	ExecutionPolicyRestricted   string = "Restricted"
+	ExecutionPolicyUndefined    string = "Undefined"
+	ExecutionPolicyUnrestricted string = "Unrestricted"
+)
+
+const (
+	ExecutionPolicyScopeCurrentUser   string = "CurrentUser"
+	ExecutionPolicyScopeLocalMachine  string = "LocalMachine"
+	ExecutionPolicyScopeMachinePolicy string = "MachinePolicy"
+	ExecutionPolicyScopeProcess       string = "Process"
+	ExecutionPolicyScopeUserPolicy    string = "UserPolicy"
+)
+
+const (
+	RemoteDesktopSecure    string = "Secure"
+	RemoteDesktopNonSecure string = "NonSecure"
+)
+
+const (
+	VirtualMemoryAutoManagePagingFile string = "AutoManagePagingFile"
+	VirtualMemoryCustomSize           string = "CustomSize"
+	VirtualMemorySystemManagedSize    string = "SystemManagedSize"
+	VirtualMemoryNoPagingFile         string = "NoPagingFile"
+)
+
+// Windows time zone IDs, as listed by tzutil /l
+//
+//go:embed catalogs/windows_time_zones.json
+var timeZonesFile []byte
+
+var (
+	timeZones     []string
+	timeZonesOnce sync.Once
+)
+
+var (
+	powerPlanGUID     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	computerName      = regexp.MustCompile(`^[A-Za-z0-9-]{1,15}$`)
+	virtualMemoryDisk = regexp.MustCompile(`^[A-Za-z]:?\\?$`)
+)
+
+// loadTimeZones panics if the catalog is not valid, it's embedded so it can only fail with a broken build
+func loadTimeZones() {
+	if err := json.Unmarshal(timeZonesFile, &timeZones); err != nil {
+		panic("wingetcfg: embedded catalog windows_time_zones.json is not valid: " + err.Error())
+	}
+}
+
+// TimeZones returns the bundled Windows time zone IDs, e.g. to offer them in a picker
+func TimeZones() []string {
+	timeZonesOnce.Do(loadTimeZones)
+	return append([]string{}, timeZones...)
+}
+
+// LookupTimeZone finds a Windows time zone ID ignoring case and returns it as Windows writes it
+func LookupTimeZone(timeZone string) (string, bool) {
+	timeZonesOnce.Do(loadTimeZones)
+	for _, tz := range timeZones {
+		if strings.EqualFold(tz, timeZone) {
+			return tz, true
+		}
+	}
+	return "", false
+}
+
+// SetTimeZone creates a new WinGetResource that sets the time zone of the system.
+// ID is an optional identifier.
+// TimeZone is required and is a Windows time zone ID, e.g. W. Europe Standard Time, see TimeZones.
+// Reference: https://github.com/dsccommunity/ComputerManagementDsc/blob/main/source/DSCResources/DSC_TimeZone/DSC_TimeZone.psm1
+func SetTimeZone(ID, timeZone string) (*WinGetResource, error) {
+	if timeZone == "" {
+		return nil, errors.New("timeZone cannot be empty")
+	}
+	tz, ok := LookupTimeZone(timeZone)
+	if !ok {
+		return nil, fmt.Errorf("time zone %s is not a valid Windows time zone ID", timeZone)
+	}
+
+	r := newComputerManagementResource(WinGetTimeZoneResource, ID, "Set time zone")
+	r.Settings["IsSingleInstance"] = "Yes"
+	r.Settings["TimeZone"] = tz
+
+	return r, nil
+}
+
+// SetPowerPlan creates a new WinGetResource that activates a power plan.
+// ID is an optional identifier.
+// Name is required and is the name of the plan, e.g. High performance, or its GUID.
+// Reference: https://github.com/dsccommunity/ComputerManagementDsc/blob/main/source/DSCResources/DSC_PowerPlan/DSC_PowerPlan.psm1
+func SetPowerPlan(ID, name string) (*WinGetResource, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+	if strings.HasPrefix(name, "{") && strings.HasSuffix(name, "}") && !powerPlanGUID.MatchString(strings.Trim(name, "{}")) {
+		return nil, fmt.Errorf("power plan %s is not a valid GUID", name)
+	}
+
+	r := newComputerManagementResource(WinGetPowerPlanResource, ID, "Set power plan")
+	r.Settings["IsSingleInstance"] = "Yes"
+	r.Settings["Name"] = strings.Trim(name, "{}")
+
+	return r, nil
+}
+
+// SetPowerShellExecutionPolicy creates a new WinGetResource that sets the PowerShell execution policy.
+// ID is an optional identifier.
+// ExecutionPolicy is required: AllSigned, Bypass, Default, RemoteSigned, Restricted, Undefined or Unrestricted.
+// Scope is CurrentUser, LocalMachine, MachinePolicy, Process or UserPolicy. If empty LocalMachine is used.
+// Reference: https://github.com/dsccommunity/ComputerManagementDsc/blob/main/source/DSCResources/DSC_PowerShellExecutionPolicy/DSC_PowerShellExecutionPolicy.psm1
+func SetPowerShellExecutionPolicy(ID, executionPolicy string, scope string) (*WinGetResource, error) {
+	switch executionPolicy {
+	case ExecutionPolicyAllSigned, ExecutionPolicyBypass, ExecutionPolicyDefault, ExecutionPolicyRemoteSigned,
+		ExecutionPolicyRestricted, ExecutionPolicyUndefined, ExecutionPolicyUnrestricted:
+	default:
+		return nil, fmt.Errorf("execution policy %s is not valid", executionPolicy)
+	}
+
+	switch scope {
+	case "":
+		scope = ExecutionPolicyScopeLocalMachine
+	case ExecutionPolicyScopeCurrentUser, ExecutionPolicyScopeLocalMachine, ExecutionPolicyScopeMachinePolicy,
+		ExecutionPolicyScopeProcess, ExecutionPolicyScopeUserPolicy:
+	default:
+		return nil, fmt.Errorf("execution policy scope %s is not valid", scope)
+	}
+
+	r := newComputerManagementResource(WinGetPowerShellExecutionPolicyResource, ID, "Set PowerShell execution policy")
+	r.Settings["ExecutionPolicy"] = executionPolicy
+	r.Settings["ExecutionPolicyScope"] = scope
+
+	return r, nil
+}
+
+// NewRemoteDesktopAdminResource creates a new WinGetResource that allows (Present) or denies (Absent) remote desktop connections.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// UserAuthentication is Secure, to require Network Level Authentication, or NonSecure. If empty it's left unchanged.
+// Reference: https://github.com/dsccommunity/ComputerManagementDsc/blob/main/source/DSCResources/DSC_RemoteDesktopAdmin/DSC_RemoteDesktopAdmin.psm1
+func NewRemoteDesktopAdminResource(ID string, description string, ensure string, userAuthentication string) (*WinGetResource, error) {
+	r := newComputerManagementResource(WinGetRemoteDesktopAdminResource, ID, description)
+	r.Settings["IsSingleInstance"] = "Yes"
+	r.Settings["Ensure"] = SetEnsureValue(ensure)
+
+	switch userAuthentication {
+	case "":
+	case RemoteDesktopSecure, RemoteDesktopNonSecure:
+		r.Settings["UserAuthentication"] = userAuthentication
+	default:
+		return nil, fmt.Errorf("user authentication %s is not valid, use Secure or NonSecure", userAuthentication)
+	}
+
+	return r, nil
+}
+
+// NewComputerResource creates a new WinGetResource that sets the name, workgroup and description of the computer.
+// ID is an optional identifier.
+// Description is an optional text that describes the computer, it's also set as the computer description.
+// Name is required and is the NetBIOS name of the computer, up to 15 letters, digits and hyphens. Use localhost
+// to keep the current name.
+// WorkGroupName is the optional workgroup the computer joins.
+// Changing the name or the workgroup requires a reboot.
+// Reference: https://github.com/dsccommunity/ComputerManagementDsc/blob/main/source/DSCResources/DSC_Computer/DSC_Computer.psm1
+func NewComputerResource(ID string, description string, name string, workGroupName string) (*WinGetResource, error) {
+	if name == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+	if !strings.EqualFold(name, "localhost") && (!computerName.MatchString(name) || strings.Trim(name, "0123456789") == "") {
+		return nil, fmt.Errorf("computer name %s is not valid, it must have up to 15 letters, digits and hyphens and cannot be only digits", name)
+	}
+
+	r := newComputerManagementResource(WinGetComputerResource, ID, description)
+	r.Settings["Name"] = name
+
+	if workGroupName != "" {
+		if len(workGroupName) > 15 || strings.ContainsAny(workGroupName, `\/:*?"<>|`) {
+			return nil, fmt.Errorf("workgroup name %s is not valid", workGroupName)
+		}
+		r.Settings["WorkGroupName"] = workGroupName
+	}
+
+	if description != "" {
+		r.Settings["Description"] = description
+	}
+
+	return r, nil
+}
+
+// NewVirtualMemoryResource creates a new WinGetResource that configures the paging file of a drive.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// Drive is required and is the drive of the paging file, e.g. C.
+// Type is AutoManagePagingFile, CustomSize, SystemManagedSize or NoPagingFile.
+// InitialSize and MaximumSize are the size of the paging file in megabytes, they're required for CustomSize.
+// Reference: https://github.com/dsccommunity/ComputerManagementDsc/blob/main/source/DSCResources/DSC_VirtualMemory/DSC_VirtualMemory.psm1
+func NewVirtualMemoryResource(ID string, description string, drive string, memoryType string, initialSize int, maximumSize int) (*WinGetResource, error) {
+	if drive == "" {
+		return nil, errors.New("drive cannot be empty")
+	}
+	if !virtualMemoryDisk.MatchString(drive) {
+		return nil, fmt.Errorf("drive %s is not valid, use a drive letter like C", drive)
+	}
+
+	r := newComputerManagementResource(WinGetVirtualMemoryResource, ID, description)
+	r.Settings["Drive"] = strings.ToUpper(drive[:1])
+
+	switch memoryType {
+	case VirtualMemoryCustomSize:
+		if initialSize <= 0 || maximumSize <= 0 {
+			return nil, errors.New("initialSize and maximumSize must be greater than 0 for CustomSize")
+		}
+		if initialSize > maximumSize {
+			return nil, errors.New("initialSize cannot be greater than maximumSize")
+		}
+		r.Settings["InitialSize"] = initialSize
+		r.Settings["MaximumSize"] = maximumSize
+	case VirtualMemoryAutoManagePagingFile, VirtualMemorySystemManagedSize, VirtualMemoryNoPagingFile:
+		if initialSize != 0 || maximumSize != 0 {
+			return nil, errors.New("initialSize and maximumSize can only be set for CustomSize")
+		}
+	default:
+		return nil, fmt.Errorf("virtual memory type %s is not valid, use AutoManagePagingFile, CustomSize, SystemManagedSize or NoPagingFile", memoryType)
+	}
+	r.Settings["Type"] = memoryType
+
+	return r, nil
+}
+
+func newComputerManagementResource(resource string, ID string, description string) *WinGetResource {
+	r := WinGetResource{}
+	r.Resource = resource
+
+	// ID (optional)
+	if ID != "" {
+		r.ID = ID
+	}
+
+	// Directives
+	r.Directives.Description = description
+	r.Directives.AllowPreRelease = true
+
+	// Settings
+	r.Settings = map[string]any{}
+
+	return &r
+}
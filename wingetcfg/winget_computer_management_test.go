@@ -0,0 +1,14 @@
+package wingetcfg
+
+import "testing"
+
+func TestTimeZonesCatalog(t *testing.T) {
+	if len(TimeZones()) == 0 {
+		t.Fatal("the time zones catalog is empty")
+	}
+
+	tz, ok := LookupTimeZone("w. europe standard time")
+	if !ok || tz != "W. Europe Standard Time" {
+		t.Errorf("got %q, %t, want W. Europe Standard Time", tz, ok)
+	}
+}
@@ -0,0 +1,327 @@
+package wingetcfg
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+type UnitOutcome string
+
+const (
+	UnitSucceeded UnitOutcome = "Succeeded"
+	UnitFailed    UnitOutcome = "Failed"
+	UnitSkipped   UnitOutcome = "Skipped"
+)
+
+const (
+	UnitActionAssert = "Assert"
+	UnitActionInform = "Inform"
+	UnitActionApply  = "Apply"
+)
+
+// ConfigurationUnitResult is the result of a configuration unit processed by winget configure.
+// Resource is the WinGetResource of the applied configuration the unit corresponds to, it's nil
+// if the unit couldn't be correlated.
+type ConfigurationUnitResult struct {
+	UnitID       string
+	ResourceType string
+	Action       string
+	Outcome      UnitOutcome
+	Error        *Error
+	Message      string
+	Resource     *WinGetResource
+}
+
+// ConfigurationResult contains the results of all the configuration units found in the output
+type ConfigurationResult struct {
+	Units []ConfigurationUnitResult
+}
+
+// Success reports whether all the units were applied successfully
+func (r *ConfigurationResult) Success() bool {
+	for _, u := range r.Units {
+		if u.Outcome != UnitSucceeded {
+			return false
+		}
+	}
+	return true
+}
+
+// Failed returns the units that failed or were skipped
+func (r *ConfigurationResult) Failed() []ConfigurationUnitResult {
+	failed := []ConfigurationUnitResult{}
+	for _, u := range r.Units {
+		if u.Outcome != UnitSucceeded {
+			failed = append(failed, u)
+		}
+	}
+	return failed
+}
+
+var (
+	configureUnitHeader = regexp.MustCompile(`^(Assert|Inform|Apply) :: (\S+)(?: \[([^\]]+)\])?\s*$`)
+	configureErrorCode  = regexp.MustCompile(`0x[0-9A-Fa-f]{8}`)
+	// configureUnitResult matches the lines winget writes with the result of a unit
+	configureUnitResult = regexp.MustCompile(`(?i)^(configuration successfully applied|((the|this) )?configuration unit\b|(loading )?the module for the configuration unit\b)`)
+)
+
+// ParseConfigureOutput parses the console output of winget configure and returns the result of each unit
+// correlated with the resources of the configuration that was applied. Each unit starts with a header
+// like "Apply :: WinGetPackage [vscode]" followed by indented lines that echo its description and settings
+// and then contain its result. Only winget's result lines, and the details that follow them, are checked,
+// so a description or a setting that looks like an error doesn't change the outcome.
+// A unit is considered successful unless its result reports that it failed or wasn't run.
+func ParseConfigureOutput(r io.Reader, cfg *WinGetCfg) (*ConfigurationResult, error) {
+	result := ConfigurationResult{}
+
+	var unit *ConfigurationUnitResult
+	// settingsIndent is the indentation of the Settings: line while its values are being echoed, -1 otherwise
+	settingsIndent := -1
+	// failed is set once a result line reports that the unit failed, the lines after it are error details
+	failed := false
+	closeUnit := func() {
+		if unit != nil {
+			result.Units = append(result.Units, *unit)
+			unit = nil
+		}
+		settingsIndent = -1
+		failed = false
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r ")
+
+		if m := configureUnitHeader.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			closeUnit()
+			unit = &ConfigurationUnitResult{Action: m[1], ResourceType: m[2], UnitID: m[3], Outcome: UnitSucceeded}
+			continue
+		}
+
+		if unit == nil || line == "" {
+			continue
+		}
+
+		// Unit blocks end with the first line that is not indented
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			closeUnit()
+			continue
+		}
+
+		text := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		// The values of the Settings section are more indented than its header
+		if settingsIndent >= 0 {
+			if indent > settingsIndent {
+				continue
+			}
+			settingsIndent = -1
+		}
+		if text == "Settings:" {
+			settingsIndent = indent
+			continue
+		}
+
+		if configureUnitResult.MatchString(text) {
+			lower := strings.ToLower(text)
+			switch {
+			case strings.HasPrefix(lower, "configuration successfully applied"):
+				unit.Outcome = UnitSucceeded
+			case strings.Contains(lower, "was not run"), strings.Contains(lower, "manually skipped"):
+				unit.Outcome = UnitSkipped
+				unit.Message = text
+			default:
+				unit.Outcome = UnitFailed
+				if unit.Message == "" {
+					unit.Message = text
+				}
+				failed = true
+			}
+		}
+
+		if !failed {
+			continue
+		}
+
+		if code := configureErrorCode.FindString(text); code != "" && unit.Error == nil {
+			if e, err := ParseError(code); err == nil {
+				unit.Error = e
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	closeUnit()
+
+	result.correlate(cfg)
+	return &result, nil
+}
+
+// ParseConfigureJSON parses the results of a configuration in JSON format, as produced by the
+// Microsoft.WinGet.Configuration PowerShell module (Invoke-WinGetConfiguration | ConvertTo-Json)
+// or by an agent that writes one JSON object per unit and line. The document can be an array of
+// unit results, an object with a UnitResults array or newline delimited unit results.
+// Unit fields are matched case insensitively: Identifier (or Id), Type (or Resource), Intent (or Action),
+// State, ResultCode (a number or an hexadecimal string) and ErrorDescription (or Message, Details).
+func ParseConfigureJSON(r io.Reader, cfg *WinGetCfg) (*ConfigurationResult, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	units := []map[string]any{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	for decoder.More() {
+		var v any
+		if err := decoder.Decode(&v); err != nil {
+			return nil, fmt.Errorf("could not parse configuration results: %v", err)
+		}
+		units = append(units, jsonUnitResults(v)...)
+	}
+
+	result := ConfigurationResult{}
+	for _, u := range units {
+		unit := ConfigurationUnitResult{
+			UnitID:       jsonField(u, "identifier", "id", "unitid"),
+			ResourceType: jsonField(u, "type", "resource", "unittype"),
+			Action:       jsonField(u, "intent", "action"),
+			Message:      jsonField(u, "errordescription", "message", "details", "description"),
+			Outcome:      UnitSucceeded,
+		}
+
+		// A result code of 0, in any form, is a success
+		if code := jsonField(u, "resultcode", "hresult", "errorcode"); code != "" {
+			e, err := ParseError(code)
+			if err != nil {
+				return nil, fmt.Errorf("unit %s: %v", unit.UnitID, err)
+			}
+			if e.Code() != 0 {
+				unit.Error = e
+				unit.Outcome = UnitFailed
+			}
+		}
+
+		state := strings.ToLower(jsonField(u, "state", "result", "outcome"))
+		switch {
+		case strings.Contains(state, "skip"), strings.Contains(state, "notrun"), strings.Contains(state, "not run"):
+			unit.Outcome = UnitSkipped
+		case strings.Contains(state, "fail"):
+			unit.Outcome = UnitFailed
+		}
+
+		if unit.Message == "" && unit.Error != nil {
+			unit.Message = unit.Error.Message()
+		}
+
+		result.Units = append(result.Units, unit)
+	}
+
+	result.correlate(cfg)
+	return &result, nil
+}
+
+// jsonUnitResults extracts the unit results from a decoded JSON value
+func jsonUnitResults(v any) []map[string]any {
+	units := []map[string]any{}
+	switch t := v.(type) {
+	case []any:
+		for _, item := range t {
+			units = append(units, jsonUnitResults(item)...)
+		}
+	case map[string]any:
+		for k, item := range t {
+			if strings.EqualFold(k, "unitresults") || strings.EqualFold(k, "units") {
+				return jsonUnitResults(item)
+			}
+		}
+		// The PowerShell module serializes the unit as a nested object, its fields are merged with the result
+		for k, item := range t {
+			if nested, ok := item.(map[string]any); ok && strings.EqualFold(k, "unit") {
+				merged := map[string]any{}
+				for nk, nv := range nested {
+					merged[nk] = nv
+				}
+				for rk, rv := range t {
+					merged[rk] = rv
+				}
+				t = merged
+				break
+			}
+		}
+		units = append(units, t)
+	}
+	return units
+}
+
+// jsonField returns the first field found with any of the names, names are compared case insensitively
+func jsonField(object map[string]any, names ...string) string {
+	for _, name := range names {
+		for k, v := range object {
+			if _, isObject := v.(map[string]any); strings.EqualFold(k, name) && v != nil && !isObject {
+				return fmt.Sprint(v)
+			}
+		}
+	}
+	return ""
+}
+
+// correlate links each unit with the resource of the configuration it comes from, units are matched by
+// their identifier and, when they have no identifier, by their type in the order they were applied
+func (r *ConfigurationResult) correlate(cfg *WinGetCfg) {
+	if cfg == nil {
+		return
+	}
+
+	used := map[*WinGetResource]bool{}
+	for i := range r.Units {
+		unit := &r.Units[i]
+
+		candidates := cfg.Properties.Resources
+		if unit.Action == UnitActionAssert {
+			candidates = cfg.Properties.Assertions
+		}
+
+		if unit.UnitID != "" {
+			for _, resource := range append(append([]*WinGetResource{}, cfg.Properties.Assertions...), cfg.Properties.Resources...) {
+				if resource != nil && !used[resource] && strings.EqualFold(resource.ID, unit.UnitID) {
+					unit.Resource = resource
+					break
+				}
+			}
+		}
+
+		if unit.Resource == nil {
+			for _, resource := range candidates {
+				if resource != nil && !used[resource] && sameResourceType(resource.Resource, unit.ResourceType) {
+					unit.Resource = resource
+					break
+				}
+			}
+		}
+
+		if unit.Resource != nil {
+			used[unit.Resource] = true
+		}
+	}
+}
+
+// sameResourceType compares resource types ignoring the module as winget configure
+// only shows the resource name, e.g. WinGetPackage for Microsoft.WinGet.DSC/WinGetPackage
+func sameResourceType(resource string, unitType string) bool {
+	if unitType == "" {
+		return false
+	}
+	if strings.EqualFold(resource, unitType) {
+		return true
+	}
+	_, name, found := strings.Cut(resource, "/")
+	return found && strings.EqualFold(name, unitType)
+}
@@ -0,0 +1,143 @@
+package wingetcfg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func parseConfigureFixture(t *testing.T, name string, cfg *WinGetCfg) *ConfigurationResult {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", "configure", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	parse := ParseConfigureOutput
+	if ext := filepath.Ext(name); ext == ".json" || ext == ".jsonl" {
+		parse = ParseConfigureJSON
+	}
+	result, err := parse(f, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestParseConfigureOutputIgnoresEchoedDescriptionAndSettings(t *testing.T) {
+	result := parseConfigureFixture(t, "description_error.txt", nil)
+
+	if len(result.Units) != 2 {
+		t.Fatalf("got %d units, want 2", len(result.Units))
+	}
+	for _, u := range result.Units {
+		if u.Outcome != UnitSucceeded || u.Error != nil || u.Message != "" {
+			t.Errorf("unit %s: got outcome %s, error %v, message %q, want a successful unit", u.UnitID, u.Outcome, u.Error, u.Message)
+		}
+	}
+	if !result.Success() {
+		t.Error("the configuration should be successful")
+	}
+}
+
+func TestParseConfigureOutputFailures(t *testing.T) {
+	vscode, err := InstallPackage("vscode", "", "Microsoft.VisualStudioCode", "winget", "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	python, err := InstallPackage("python", "", "Python.Python.3.12", "winget", "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	git, err := InstallPackage("", "", "Git.Git", "winget", "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := NewWingetCfg()
+	cfg.AddResource(vscode)
+	cfg.AddResource(python)
+	cfg.AddResource(git)
+
+	result := parseConfigureFixture(t, "failures.txt", cfg)
+
+	checkUnits(t, result, []wantUnit{
+		{"os", UnitActionAssert, UnitSucceeded, ""},
+		{"vscode", UnitActionApply, UnitFailed, "0x8A15C005"},
+		{"python", UnitActionApply, UnitSkipped, ""},
+		{"", UnitActionApply, UnitSucceeded, ""},
+	})
+
+	if result.Units[1].Message != "The configuration unit failed while attempting to apply the desired state." {
+		t.Errorf("unexpected message %q", result.Units[1].Message)
+	}
+	if result.Units[1].Resource != vscode {
+		t.Error("the vscode unit should be correlated with its resource")
+	}
+	if result.Units[3].Resource != git {
+		t.Error("the unit without identifier should be correlated by its type")
+	}
+	if result.Success() || len(result.Failed()) != 2 {
+		t.Errorf("got %d failed units, want 2", len(result.Failed()))
+	}
+}
+
+type wantUnit struct {
+	id      string
+	action  string
+	outcome UnitOutcome
+	code    string
+}
+
+func checkUnits(t *testing.T, result *ConfigurationResult, want []wantUnit) {
+	t.Helper()
+	if len(result.Units) != len(want) {
+		t.Fatalf("got %d units, want %d", len(result.Units), len(want))
+	}
+	for i, w := range want {
+		u := result.Units[i]
+		code := ""
+		if u.Error != nil {
+			code = u.Error.Hex()
+		}
+		if u.UnitID != w.id || u.Action != w.action || u.Outcome != w.outcome || code != w.code {
+			t.Errorf("unit %d: got %s %s %s %q, want %s %s %s %q", i, u.Action, u.UnitID, u.Outcome, code, w.action, w.id, w.outcome, w.code)
+		}
+	}
+}
+
+func TestParseConfigureJSONUnitResults(t *testing.T) {
+	vscode, err := InstallPackage("vscode", "", "Microsoft.VisualStudioCode", "winget", "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := NewWingetCfg()
+	cfg.AddResource(vscode)
+
+	result := parseConfigureFixture(t, "results.json", cfg)
+	checkUnits(t, result, []wantUnit{
+		{"vscode", UnitActionApply, UnitSucceeded, ""},
+		{"python", UnitActionApply, UnitFailed, "0x8A15C005"},
+		{"git", UnitActionApply, UnitSkipped, ""},
+	})
+
+	if result.Units[0].Resource != vscode {
+		t.Error("the vscode unit should be correlated with its resource")
+	}
+	if result.Units[1].Message != "Package installation failed" {
+		t.Errorf("unexpected message %q", result.Units[1].Message)
+	}
+}
+
+func TestParseConfigureJSONLines(t *testing.T) {
+	result := parseConfigureFixture(t, "results.jsonl", nil)
+	checkUnits(t, result, []wantUnit{
+		{"os", UnitActionAssert, UnitSucceeded, ""},
+		{"vscode", UnitActionApply, UnitFailed, "0x8A15C005"},
+		{"python", UnitActionApply, UnitSucceeded, ""},
+	})
+
+	if result.Units[1].Message != ErrorCodes["0x8A15C005"] {
+		t.Errorf("units without message should use the description of the error, got %q", result.Units[1].Message)
+	}
+}
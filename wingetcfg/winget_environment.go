@@ -0,0 +1,122 @@
+package wingetcfg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	WinGetEnvironmentResource = "xPSDesiredStateConfiguration/xEnvironment"
+)
+
+const (
+	EnvironmentTargetMachine string = "Machine"
+	EnvironmentTargetProcess string = "Process"
+)
+
+// SetEnvironmentVariable creates or replaces the value of an environment variable.
+// ID is an optional identifier.
+// Name is required and is the name of the environment variable.
+// Value is required and is the value of the environment variable.
+// Targets are Machine and Process, if empty the variable is set in both.
+func SetEnvironmentVariable(ID, name string, value string, targets []string) (*WinGetResource, error) {
+	return NewEnvironmentResource(ID, "", name, value, false, targets, EnsurePresent)
+}
+
+// RemoveEnvironmentVariable removes an environment variable.
+// ID is an optional identifier.
+// Name is required and is the name of the environment variable.
+// Targets are Machine and Process, if empty the variable is removed from both.
+func RemoveEnvironmentVariable(ID, name string, targets []string) (*WinGetResource, error) {
+	return NewEnvironmentResource(ID, "", name, "", false, targets, EnsureAbsent)
+}
+
+// AddToPath appends a single entry to the PATH environment variable, the rest of the entries are kept.
+// Add the ID of the package that installs the tool to DependsOn so the entry is added after it's installed.
+// ID is an optional identifier.
+// Entry is required and is the directory to add.
+// Targets are Machine and Process, if empty the entry is added to both.
+func AddToPath(ID, entry string, targets []string) (*WinGetResource, error) {
+	return NewEnvironmentResource(ID, "", "Path", entry, true, targets, EnsurePresent)
+}
+
+// RemoveFromPath removes a single entry from the PATH environment variable, the rest of the entries are kept.
+// ID is an optional identifier.
+// Entry is required and is the directory to remove.
+// Targets are Machine and Process, if empty the entry is removed from both.
+func RemoveFromPath(ID, entry string, targets []string) (*WinGetResource, error) {
+	return NewEnvironmentResource(ID, "", "Path", entry, true, targets, EnsureAbsent)
+}
+
+// NewEnvironmentResource creates a new WinGetResource that contains the settings to manage an environment variable.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// Name is required and is the name of the environment variable.
+// Value is the value of the environment variable, it's required when Ensure is Present.
+// Path specifies whether the variable is a list of paths separated by semicolons. If true, the value
+// is a single entry that's appended when Ensure is Present or removed when Ensure is Absent, without
+// changing the other entries. If false, the whole variable is replaced or removed.
+// Targets are Machine and Process, if empty the resource's default, both, is used.
+// Ensure specifies whether the variable, or the entry if Path is true, should exist.
+// Reference: https://github.com/dsccommunity/xPSDesiredStateConfiguration/blob/main/source/DSCResources/DSC_xEnvironmentResource/DSC_xEnvironmentResource.psm1
+func NewEnvironmentResource(ID, description string, name string, value string, path bool, targets []string, ensure string) (*WinGetResource, error) {
+	r := WinGetResource{}
+	r.Resource = WinGetEnvironmentResource
+
+	// ID (optional)
+	if ID != "" {
+		r.ID = ID
+	}
+
+	// Directives
+	r.Directives.Description = description
+	r.Directives.AllowPreRelease = true
+
+	// Settings
+	r.Settings = map[string]any{}
+
+	if name == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+	r.Settings["Name"] = name
+
+	ensure = SetEnsureValue(ensure)
+	r.Settings["Ensure"] = ensure
+
+	if path {
+		if value == "" {
+			return nil, errors.New("value cannot be empty if path is true")
+		}
+		// Several entries would be appended or removed as a whole
+		if strings.Contains(value, ";") {
+			return nil, fmt.Errorf("value %s must be a single path entry", value)
+		}
+		r.Settings["Path"] = true
+	} else if value == "" && ensure == EnsurePresent {
+		return nil, errors.New("value cannot be empty")
+	}
+
+	if value != "" {
+		r.Settings["Value"] = value
+	}
+
+	if len(targets) > 0 {
+		seen := map[string]bool{}
+		target := []string{}
+		for _, t := range targets {
+			switch t {
+			case EnvironmentTargetMachine, EnvironmentTargetProcess:
+			default:
+				return nil, fmt.Errorf("environment target %s is not valid, use Machine or Process", t)
+			}
+			if !seen[t] {
+				seen[t] = true
+				target = append(target, t)
+			}
+		}
+		r.Settings["Target"] = target
+	}
+
+	return &r, nil
+}
@@ -0,0 +1,214 @@
+package wingetcfg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//go:generate go run ./internal/errorcodesgen -in internal/errorcodesgen/returnCodes.md -out winget_error_codes.go
+
+type ErrorCategory string
+
+const (
+	ErrorCategoryGeneral           ErrorCategory = "general"
+	ErrorCategoryInstall           ErrorCategory = "install"
+	ErrorCategorySource            ErrorCategory = "source"
+	ErrorCategoryManifest          ErrorCategory = "manifest"
+	ErrorCategoryConfiguration     ErrorCategory = "configuration"
+	ErrorCategoryConfigurationUnit ErrorCategory = "configuration-unit"
+	ErrorCategoryRest              ErrorCategory = "rest"
+)
+
+// Error is a WinGet error identified by its HRESULT
+type Error struct {
+	code uint32
+}
+
+// ErrorCodeInfo describes a WinGet error code as documented in returnCodes.md
+type ErrorCodeInfo struct {
+	Hex         string
+	Decimal     int32
+	Symbol      string
+	Description string
+}
+
+// Sentinel errors that can be used with errors.Is
+var (
+	ErrInternal                     = NewError(uint32(0x8A150001))
+	ErrInvalidArguments             = NewError(uint32(0x8A150002))
+	ErrCommandFailed                = NewError(uint32(0x8A150003))
+	ErrDownloadFailed               = NewError(uint32(0x8A150008))
+	ErrSourceNameAlreadyExists      = NewError(uint32(0x8A15000C))
+	ErrNoApplicableInstaller        = NewError(uint32(0x8A150010))
+	ErrInstallerHashMismatch        = NewError(uint32(0x8A150011))
+	ErrSourceNameDoesNotExist       = NewError(uint32(0x8A150012))
+	ErrNoPackagesFound              = NewError(uint32(0x8A150014))
+	ErrNoSourcesConfigured          = NewError(uint32(0x8A150015))
+	ErrMultiplePackagesFound        = NewError(uint32(0x8A150016))
+	ErrNoManifestFound              = NewError(uint32(0x8A150017))
+	ErrCommandRequiresAdmin         = NewError(uint32(0x8A150019))
+	ErrInvalidManifest              = NewError(uint32(0x8A15002A))
+	ErrUpdateNotApplicable          = NewError(uint32(0x8A15002B))
+	ErrBlockedByPolicy              = NewError(uint32(0x8A15003A))
+	ErrPackageAgreementsNotAccepted = NewError(uint32(0x8A150041))
+	ErrSourceAgreementsNotAccepted  = NewError(uint32(0x8A150046))
+	ErrMSIInstallFailed             = NewError(uint32(0x8A150049))
+	ErrPackageAlreadyInstalled      = NewError(uint32(0x8A150061))
+	ErrConfigurationFileInvalid     = NewError(uint32(0x8A15C001))
+	ErrConfigurationInvalidYAML     = NewError(uint32(0x8A15C002))
+	ErrConfigurationApplyFailed     = NewError(uint32(0x8A15C005))
+	ErrConfigurationAssertionFailed = NewError(uint32(0x8A15C009))
+	ErrConfigurationUnitNotFound    = NewError(uint32(0x8A15C102))
+	ErrConfigurationUnitSetFailed   = NewError(uint32(0x8A15C106))
+)
+
+// Codes in the general range that belong to a more specific category
+var errorCodeCategories = map[uint32]ErrorCategory{
+	0x8A150004: ErrorCategoryManifest,
+	0x8A150006: ErrorCategoryInstall,
+	0x8A150007: ErrorCategoryManifest,
+	0x8A150008: ErrorCategoryInstall,
+	0x8A150009: ErrorCategorySource,
+	0x8A15000A: ErrorCategorySource,
+	0x8A15000B: ErrorCategorySource,
+	0x8A15000C: ErrorCategorySource,
+	0x8A15000D: ErrorCategorySource,
+	0x8A15000F: ErrorCategorySource,
+	0x8A150010: ErrorCategoryInstall,
+	0x8A150011: ErrorCategoryInstall,
+	0x8A150012: ErrorCategorySource,
+	0x8A150013: ErrorCategorySource,
+	0x8A150015: ErrorCategorySource,
+	0x8A150017: ErrorCategoryManifest,
+	0x8A15001A: ErrorCategorySource,
+	0x8A150020: ErrorCategoryManifest,
+	0x8A150021: ErrorCategoryManifest,
+	0x8A150022: ErrorCategoryManifest,
+	0x8A150023: ErrorCategoryManifest,
+	0x8A150024: ErrorCategoryManifest,
+	0x8A150025: ErrorCategoryManifest,
+	0x8A150026: ErrorCategoryManifest,
+	0x8A150027: ErrorCategoryManifest,
+	0x8A150028: ErrorCategoryManifest,
+	0x8A150029: ErrorCategoryManifest,
+	0x8A15002A: ErrorCategoryManifest,
+	0x8A15002D: ErrorCategoryInstall,
+	0x8A15002E: ErrorCategoryInstall,
+	0x8A15002F: ErrorCategoryInstall,
+	0x8A150030: ErrorCategoryInstall,
+	0x8A150034: ErrorCategoryInstall,
+	0x8A150037: ErrorCategorySource,
+	0x8A150038: ErrorCategoryRest,
+	0x8A150039: ErrorCategoryRest,
+	0x8A15003B: ErrorCategoryRest,
+	0x8A15003C: ErrorCategoryRest,
+	0x8A15003D: ErrorCategoryRest,
+	0x8A15003E: ErrorCategoryRest,
+	0x8A15003F: ErrorCategorySource,
+	0x8A150043: ErrorCategorySource,
+	0x8A150044: ErrorCategoryRest,
+	0x8A150045: ErrorCategorySource,
+	0x8A150046: ErrorCategorySource,
+	0x8A150047: ErrorCategoryRest,
+	0x8A150049: ErrorCategoryInstall,
+	0x8A15004A: ErrorCategoryInstall,
+	0x8A15004B: ErrorCategorySource,
+	0x8A150052: ErrorCategoryInstall,
+	0x8A150056: ErrorCategoryInstall,
+	0x8A150057: ErrorCategoryInstall,
+	0x8A15005B: ErrorCategoryInstall,
+	0x8A15005C: ErrorCategoryInstall,
+	0x8A15005D: ErrorCategoryInstall,
+	0x8A150060: ErrorCategoryInstall,
+	0x8A150061: ErrorCategoryInstall,
+	0x8A150065: ErrorCategoryInstall,
+	0x8A150066: ErrorCategoryInstall,
+	0x8A150086: ErrorCategoryInstall,
+}
+
+// NewError creates a WinGet error from its HRESULT, either signed as returned
+// as process exit code (e.g. -1978335212) or unsigned (e.g. 0x8A150014)
+func NewError[T ~int | ~int32 | ~int64 | ~uint32](hresult T) *Error {
+	return &Error{code: uint32(hresult)}
+}
+
+// ParseError parses a WinGet error code given as a decimal exit code (e.g. -1978335212 or 2316632084)
+// or as an hexadecimal string in any case, with or without the 0x prefix (e.g. 0x8a150014 or 8A150014)
+func ParseError(code string) (*Error, error) {
+	code = strings.TrimSpace(code)
+
+	if v, err := strconv.ParseInt(code, 10, 64); err == nil {
+		if v < -(1<<31) || v > 1<<32-1 {
+			return nil, fmt.Errorf("error code %s is out of range", code)
+		}
+		return NewError(v), nil
+	}
+
+	hex := strings.TrimPrefix(strings.TrimPrefix(code, "0x"), "0X")
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("error code %s is not a valid decimal or hexadecimal code", code)
+	}
+	return NewError(uint32(v)), nil
+}
+
+// Code returns the HRESULT of the error
+func (e *Error) Code() uint32 {
+	return e.code
+}
+
+// ExitCode returns the HRESULT as the signed exit code returned by the winget process
+func (e *Error) ExitCode() int32 {
+	return int32(e.code)
+}
+
+// Hex returns the HRESULT as an hexadecimal string, the format used as key in ErrorCodes
+func (e *Error) Hex() string {
+	return fmt.Sprintf("0x%08X", e.code)
+}
+
+// Message returns the description of the error found in ErrorCodes
+func (e *Error) Message() string {
+	if message, ok := ErrorCodes[e.Hex()]; ok {
+		return message
+	}
+	return "Unknown error"
+}
+
+// Symbol returns the symbolic name of the error, e.g. APPINSTALLER_CLI_ERROR_NO_APPLICATIONS_FOUND
+func (e *Error) Symbol() string {
+	for _, info := range ErrorCodeTable {
+		if uint32(info.Decimal) == e.code {
+			return info.Symbol
+		}
+	}
+	return ""
+}
+
+// Category returns the category of the error according to its code
+func (e *Error) Category() ErrorCategory {
+	switch {
+	case e.code >= 0x8A150101 && e.code <= 0x8A1502FF:
+		return ErrorCategoryInstall
+	case e.code >= 0x8A15C000 && e.code <= 0x8A15C0FF:
+		return ErrorCategoryConfiguration
+	case e.code >= 0x8A15C100 && e.code <= 0x8A15C1FF:
+		return ErrorCategoryConfigurationUnit
+	}
+
+	if category, ok := errorCodeCategories[e.code]; ok {
+		return category
+	}
+	return ErrorCategoryGeneral
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Hex(), e.Message())
+}
+
+// Is reports whether the target is a WinGet error with the same code
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t != nil && t.code == e.code
+}
@@ -1,3 +1,5 @@
+// Code generated by errorcodesgen from internal/errorcodesgen/returnCodes.md; DO NOT EDIT.
+
 package wingetcfg
 
 // ErrorCodes for WinGet
@@ -204,3 +206,207 @@ var ErrorCodes = map[string]string{
 	"0x8A15C111": "Loading the module for the configuration unit failed because it requires administrator privileges to run.",
 	"0x8A15C112": "Operation is not supported by the configuration processor.",
 }
+
+// ErrorCodeTable contains the WinGet error codes with their decimal values and symbolic names
+var ErrorCodeTable = []ErrorCodeInfo{
+	{Hex: "0x8A150001", Decimal: -1978335231, Symbol: "APPINSTALLER_CLI_ERROR_INTERNAL_ERROR", Description: "Internal Error"},
+	{Hex: "0x8A150002", Decimal: -1978335230, Symbol: "APPINSTALLER_CLI_ERROR_INVALID_CL_ARGUMENTS", Description: "Invalid command line arguments"},
+	{Hex: "0x8A150003", Decimal: -1978335229, Symbol: "APPINSTALLER_CLI_ERROR_COMMAND_FAILED", Description: "Executing command failed"},
+	{Hex: "0x8A150004", Decimal: -1978335228, Symbol: "APPINSTALLER_CLI_ERROR_MANIFEST_FAILED", Description: "Opening manifest failed"},
+	{Hex: "0x8A150005", Decimal: -1978335227, Symbol: "APPINSTALLER_CLI_ERROR_CTRL_SIGNAL_RECEIVED", Description: "Cancellation signal received"},
+	{Hex: "0x8A150006", Decimal: -1978335226, Symbol: "APPINSTALLER_CLI_ERROR_SHELLEXEC_INSTALL_FAILED", Description: "Running ShellExecute failed"},
+	{Hex: "0x8A150007", Decimal: -1978335225, Symbol: "APPINSTALLER_CLI_ERROR_UNSUPPORTED_MANIFESTVERSION", Description: "Cannot process manifest. The manifest version is higher than supported. Please update the client."},
+	{Hex: "0x8A150008", Decimal: -1978335224, Symbol: "APPINSTALLER_CLI_ERROR_DOWNLOAD_FAILED", Description: "Downloading installer failed"},
+	{Hex: "0x8A150009", Decimal: -1978335223, Symbol: "APPINSTALLER_CLI_ERROR_CANNOT_WRITE_TO_UPLEVEL_INDEX", Description: "Cannot write to index; it is a higher schema version"},
+	{Hex: "0x8A15000A", Decimal: -1978335222, Symbol: "APPINSTALLER_CLI_ERROR_INDEX_INTEGRITY_COMPROMISED", Description: "The index is corrupt"},
+	{Hex: "0x8A15000B", Decimal: -1978335221, Symbol: "APPINSTALLER_CLI_ERROR_SOURCES_INVALID", Description: "The configured source information is corrupt"},
+	{Hex: "0x8A15000C", Decimal: -1978335220, Symbol: "APPINSTALLER_CLI_ERROR_SOURCE_NAME_ALREADY_EXISTS", Description: "The source name is already configured"},
+	{Hex: "0x8A15000D", Decimal: -1978335219, Symbol: "APPINSTALLER_CLI_ERROR_INVALID_SOURCE_TYPE", Description: "The source type is invalid"},
+	{Hex: "0x8A15000E", Decimal: -1978335218, Symbol: "APPINSTALLER_CLI_ERROR_PACKAGE_IS_BUNDLE", Description: "The MSIX file is a bundle, not a package"},
+	{Hex: "0x8A15000F", Decimal: -1978335217, Symbol: "APPINSTALLER_CLI_ERROR_SOURCE_DATA_MISSING", Description: "Data required by the source is missing"},
+	{Hex: "0x8A150010", Decimal: -1978335216, Symbol: "APPINSTALLER_CLI_ERROR_NO_APPLICABLE_INSTALLER", Description: "None of the installers are applicable for the current system"},
+	{Hex: "0x8A150011", Decimal: -1978335215, Symbol: "APPINSTALLER_CLI_ERROR_INSTALLER_HASH_MISMATCH", Description: "The installer file's hash does not match the manifest"},
+	{Hex: "0x8A150012", Decimal: -1978335214, Symbol: "APPINSTALLER_CLI_ERROR_SOURCE_NAME_DOES_NOT_EXIST", Description: "The source name does not exist"},
+	{Hex: "0x8A150013", Decimal: -1978335213, Symbol: "APPINSTALLER_CLI_ERROR_SOURCE_ARG_ALREADY_EXISTS", Description: "The source location is already configured under another name"},
+	{Hex: "0x8A150014", Decimal: -1978335212, Symbol: "APPINSTALLER_CLI_ERROR_NO_APPLICATIONS_FOUND", Description: "No packages found"},
+	{Hex: "0x8A150015", Decimal: -1978335211, Symbol: "APPINSTALLER_CLI_ERROR_NO_SOURCES_DEFINED", Description: "No sources are configured"},
+	{Hex: "0x8A150016", Decimal: -1978335210, Symbol: "APPINSTALLER_CLI_ERROR_MULTIPLE_APPLICATIONS_FOUND", Description: "Multiple packages found matching the criteria"},
+	{Hex: "0x8A150017", Decimal: -1978335209, Symbol: "APPINSTALLER_CLI_ERROR_NO_MANIFEST_FOUND", Description: "No manifest found matching the criteria"},
+	{Hex: "0x8A150018", Decimal: -1978335208, Symbol: "APPINSTALLER_CLI_ERROR_EXTENSION_PUBLIC_FAILED", Description: "Failed to get Public folder from source package"},
+	{Hex: "0x8A150019", Decimal: -1978335207, Symbol: "APPINSTALLER_CLI_ERROR_COMMAND_REQUIRES_ADMIN", Description: "Command requires administrator privileges to run"},
+	{Hex: "0x8A15001A", Decimal: -1978335206, Symbol: "APPINSTALLER_CLI_ERROR_SOURCE_NOT_SECURE", Description: "The source location is not secure"},
+	{Hex: "0x8A15001B", Decimal: -1978335205, Symbol: "APPINSTALLER_CLI_ERROR_MSSTORE_BLOCKED_BY_POLICY", Description: "The Microsoft Store client is blocked by policy"},
+	{Hex: "0x8A15001C", Decimal: -1978335204, Symbol: "APPINSTALLER_CLI_ERROR_MSSTORE_APP_BLOCKED_BY_POLICY", Description: "The Microsoft Store app is blocked by policy"},
+	{Hex: "0x8A15001D", Decimal: -1978335203, Symbol: "APPINSTALLER_CLI_ERROR_EXPERIMENTAL_FEATURE_DISABLED", Description: "The feature is currently under development. It can be enabled using winget settings."},
+	{Hex: "0x8A15001E", Decimal: -1978335202, Symbol: "APPINSTALLER_CLI_ERROR_MSSTORE_INSTALL_FAILED", Description: "Failed to install the Microsoft Store app"},
+	{Hex: "0x8A15001F", Decimal: -1978335201, Symbol: "APPINSTALLER_CLI_ERROR_COMPLETE_INPUT_BAD", Description: "Failed to perform auto complete"},
+	{Hex: "0x8A150020", Decimal: -1978335200, Symbol: "APPINSTALLER_CLI_ERROR_YAML_INIT_FAILED", Description: "Failed to initialize YAML parser"},
+	{Hex: "0x8A150021", Decimal: -1978335199, Symbol: "APPINSTALLER_CLI_ERROR_YAML_INVALID_MAPPING_KEY", Description: "Encountered an invalid YAML key"},
+	{Hex: "0x8A150022", Decimal: -1978335198, Symbol: "APPINSTALLER_CLI_ERROR_YAML_DUPLICATE_MAPPING_KEY", Description: "Encountered a duplicate YAML key"},
+	{Hex: "0x8A150023", Decimal: -1978335197, Symbol: "APPINSTALLER_CLI_ERROR_YAML_INVALID_OPERATION", Description: "Invalid YAML operation"},
+	{Hex: "0x8A150024", Decimal: -1978335196, Symbol: "APPINSTALLER_CLI_ERROR_YAML_DOC_BUILD_FAILED", Description: "Failed to build YAML doc"},
+	{Hex: "0x8A150025", Decimal: -1978335195, Symbol: "APPINSTALLER_CLI_ERROR_YAML_INVALID_EMITTER_STATE", Description: "Invalid YAML emitter state"},
+	{Hex: "0x8A150026", Decimal: -1978335194, Symbol: "APPINSTALLER_CLI_ERROR_YAML_INVALID_DATA", Description: "Invalid YAML data"},
+	{Hex: "0x8A150027", Decimal: -1978335193, Symbol: "APPINSTALLER_CLI_ERROR_LIBYAML_ERROR", Description: "LibYAML error"},
+	{Hex: "0x8A150028", Decimal: -1978335192, Symbol: "APPINSTALLER_CLI_ERROR_MANIFEST_VALIDATION_WARNING", Description: "Manifest validation succeeded with warning"},
+	{Hex: "0x8A150029", Decimal: -1978335191, Symbol: "APPINSTALLER_CLI_ERROR_MANIFEST_VALIDATION_FAILURE", Description: "Manifest validation failed"},
+	{Hex: "0x8A15002A", Decimal: -1978335190, Symbol: "APPINSTALLER_CLI_ERROR_INVALID_MANIFEST", Description: "Manifest is invalid"},
+	{Hex: "0x8A15002B", Decimal: -1978335189, Symbol: "APPINSTALLER_CLI_ERROR_UPDATE_NOT_APPLICABLE", Description: "No applicable update found"},
+	{Hex: "0x8A15002C", Decimal: -1978335188, Symbol: "APPINSTALLER_CLI_ERROR_UPDATE_ALL_HAS_FAILURE", Description: "winget upgrade ::all completed with failures"},
+	{Hex: "0x8A15002D", Decimal: -1978335187, Symbol: "APPINSTALLER_CLI_ERROR_INSTALLER_SECURITY_CHECK_FAILED", Description: "Installer failed security check"},
+	{Hex: "0x8A15002E", Decimal: -1978335186, Symbol: "APPINSTALLER_CLI_ERROR_DOWNLOAD_SIZE_MISMATCH", Description: "Download size does not match expected content length"},
+	{Hex: "0x8A15002F", Decimal: -1978335185, Symbol: "APPINSTALLER_CLI_ERROR_NO_UNINSTALL_INFO_FOUND", Description: "Uninstall command not found"},
+	{Hex: "0x8A150030", Decimal: -1978335184, Symbol: "APPINSTALLER_CLI_ERROR_EXEC_UNINSTALL_COMMAND_FAILED", Description: "Running uninstall command failed"},
+	{Hex: "0x8A150031", Decimal: -1978335183, Symbol: "APPINSTALLER_CLI_ERROR_ICU_BREAK_ITERATOR_ERROR", Description: "ICU break iterator error"},
+	{Hex: "0x8A150032", Decimal: -1978335182, Symbol: "APPINSTALLER_CLI_ERROR_ICU_CASEMAP_ERROR", Description: "ICU casemap error"},
+	{Hex: "0x8A150033", Decimal: -1978335181, Symbol: "APPINSTALLER_CLI_ERROR_ICU_REGEX_ERROR", Description: "ICU regex error"},
+	{Hex: "0x8A150034", Decimal: -1978335180, Symbol: "APPINSTALLER_CLI_ERROR_IMPORT_INSTALL_FAILED", Description: "Failed to install one or more imported packages"},
+	{Hex: "0x8A150035", Decimal: -1978335179, Symbol: "APPINSTALLER_CLI_ERROR_NOT_ALL_PACKAGES_FOUND", Description: "Could not find one or more requested packages"},
+	{Hex: "0x8A150036", Decimal: -1978335178, Symbol: "APPINSTALLER_CLI_ERROR_JSON_INVALID_FILE", Description: "Json file is invalid"},
+	{Hex: "0x8A150037", Decimal: -1978335177, Symbol: "APPINSTALLER_CLI_ERROR_SOURCE_NOT_REMOTE", Description: "The source location is not remote"},
+	{Hex: "0x8A150038", Decimal: -1978335176, Symbol: "APPINSTALLER_CLI_ERROR_UNSUPPORTED_RESTSOURCE", Description: "The configured rest source is not supported"},
+	{Hex: "0x8A150039", Decimal: -1978335175, Symbol: "APPINSTALLER_CLI_ERROR_RESTSOURCE_INVALID_DATA", Description: "Invalid data returned by rest source"},
+	{Hex: "0x8A15003A", Decimal: -1978335174, Symbol: "APPINSTALLER_CLI_ERROR_BLOCKED_BY_POLICY", Description: "Operation is blocked by Group Policy"},
+	{Hex: "0x8A15003B", Decimal: -1978335173, Symbol: "APPINSTALLER_CLI_ERROR_RESTAPI_INTERNAL_ERROR", Description: "Rest API internal error"},
+	{Hex: "0x8A15003C", Decimal: -1978335172, Symbol: "APPINSTALLER_CLI_ERROR_RESTSOURCE_INVALID_URL", Description: "Invalid rest source url"},
+	{Hex: "0x8A15003D", Decimal: -1978335171, Symbol: "APPINSTALLER_CLI_ERROR_RESTAPI_UNSUPPORTED_MIME_TYPE", Description: "Unsupported MIME type returned by rest API"},
+	{Hex: "0x8A15003E", Decimal: -1978335170, Symbol: "APPINSTALLER_CLI_ERROR_RESTSOURCE_INVALID_VERSION", Description: "Invalid rest source contract version"},
+	{Hex: "0x8A15003F", Decimal: -1978335169, Symbol: "APPINSTALLER_CLI_ERROR_SOURCE_DATA_INTEGRITY_FAILURE", Description: "The source data is corrupted or tampered"},
+	{Hex: "0x8A150040", Decimal: -1978335168, Symbol: "APPINSTALLER_CLI_ERROR_STREAM_READ_FAILURE", Description: "Error reading from the stream"},
+	{Hex: "0x8A150041", Decimal: -1978335167, Symbol: "APPINSTALLER_CLI_ERROR_PACKAGE_AGREEMENTS_NOT_ACCEPTED", Description: "Package agreements were not agreed to"},
+	{Hex: "0x8A150042", Decimal: -1978335166, Symbol: "APPINSTALLER_CLI_ERROR_PROMPT_INPUT_ERROR", Description: "Error reading input in prompt"},
+	{Hex: "0x8A150043", Decimal: -1978335165, Symbol: "APPINSTALLER_CLI_ERROR_UNSUPPORTED_SOURCE_REQUEST", Description: "The search request is not supported by one or more sources"},
+	{Hex: "0x8A150044", Decimal: -1978335164, Symbol: "APPINSTALLER_CLI_ERROR_RESTAPI_ENDPOINT_NOT_FOUND", Description: "The rest API endpoint is not found."},
+	{Hex: "0x8A150045", Decimal: -1978335163, Symbol: "APPINSTALLER_CLI_ERROR_SOURCE_OPEN_FAILED", Description: "Failed to open the source."},
+	{Hex: "0x8A150046", Decimal: -1978335162, Symbol: "APPINSTALLER_CLI_ERROR_SOURCE_AGREEMENTS_NOT_ACCEPTED", Description: "Source agreements were not agreed to"},
+	{Hex: "0x8A150047", Decimal: -1978335161, Symbol: "APPINSTALLER_CLI_ERROR_CUSTOMHEADER_EXCEEDS_MAXLENGTH", Description: "Header size exceeds the allowable limit of 1024 characters. Please reduce the size and try again."},
+	{Hex: "0x8A150048", Decimal: -1978335160, Symbol: "APPINSTALLER_CLI_ERROR_MISSING_RESOURCE_FILE", Description: "Missing resource file"},
+	{Hex: "0x8A150049", Decimal: -1978335159, Symbol: "APPINSTALLER_CLI_ERROR_MSI_INSTALL_FAILED", Description: "Running MSI install failed"},
+	{Hex: "0x8A15004A", Decimal: -1978335158, Symbol: "APPINSTALLER_CLI_ERROR_INVALID_MSIEXEC_ARGUMENT", Description: "Arguments for msiexec are invalid"},
+	{Hex: "0x8A15004B", Decimal: -1978335157, Symbol: "APPINSTALLER_CLI_ERROR_FAILED_TO_OPEN_ALL_SOURCES", Description: "Failed to open one or more sources"},
+	{Hex: "0x8A15004C", Decimal: -1978335156, Symbol: "APPINSTALLER_CLI_ERROR_DEPENDENCIES_VALIDATION_FAILED", Description: "Failed to validate dependencies"},
+	{Hex: "0x8A15004D", Decimal: -1978335155, Symbol: "APPINSTALLER_CLI_ERROR_MISSING_PACKAGE", Description: "One or more package is missing"},
+	{Hex: "0x8A15004E", Decimal: -1978335154, Symbol: "APPINSTALLER_CLI_ERROR_INVALID_TABLE_COLUMN", Description: "Invalid table column"},
+	{Hex: "0x8A15004F", Decimal: -1978335153, Symbol: "APPINSTALLER_CLI_ERROR_UPGRADE_VERSION_NOT_NEWER", Description: "The upgrade version is not newer than the installed version"},
+	{Hex: "0x8A150050", Decimal: -1978335152, Symbol: "APPINSTALLER_CLI_ERROR_UPGRADE_VERSION_UNKNOWN", Description: "Upgrade version is unknown and override is not specified"},
+	{Hex: "0x8A150051", Decimal: -1978335151, Symbol: "APPINSTALLER_CLI_ERROR_ICU_CONVERSION_ERROR", Description: "ICU conversion error"},
+	{Hex: "0x8A150052", Decimal: -1978335150, Symbol: "APPINSTALLER_CLI_ERROR_PORTABLE_INSTALL_FAILED", Description: "Failed to install portable package"},
+	{Hex: "0x8A150053", Decimal: -1978335149, Symbol: "APPINSTALLER_CLI_ERROR_PORTABLE_REPARSE_POINT_NOT_SUPPORTED", Description: "Volume does not support reparse points."},
+	{Hex: "0x8A150054", Decimal: -1978335148, Symbol: "APPINSTALLER_CLI_ERROR_PORTABLE_PACKAGE_ALREADY_EXISTS", Description: "Portable package from a different source already exists."},
+	{Hex: "0x8A150055", Decimal: -1978335147, Symbol: "APPINSTALLER_CLI_ERROR_PORTABLE_SYMLINK_PATH_IS_DIRECTORY", Description: "Unable to create symlink, path points to a directory."},
+	{Hex: "0x8A150056", Decimal: -1978335146, Symbol: "APPINSTALLER_CLI_ERROR_INSTALLER_PROHIBITS_ELEVATION", Description: "The installer cannot be run from an administrator context."},
+	{Hex: "0x8A150057", Decimal: -1978335145, Symbol: "APPINSTALLER_CLI_ERROR_PORTABLE_UNINSTALL_FAILED", Description: "Failed to uninstall portable package"},
+	{Hex: "0x8A150058", Decimal: -1978335144, Symbol: "APPINSTALLER_CLI_ERROR_ARP_VERSION_VALIDATION_FAILED", Description: "Failed to validate DisplayVersion values against index."},
+	{Hex: "0x8A150059", Decimal: -1978335143, Symbol: "APPINSTALLER_CLI_ERROR_UNSUPPORTED_ARGUMENT", Description: "One or more arguments are not supported."},
+	{Hex: "0x8A15005A", Decimal: -1978335142, Symbol: "APPINSTALLER_CLI_ERROR_BIND_WITH_EMBEDDED_NULL", Description: "Embedded null characters are disallowed for SQLite"},
+	{Hex: "0x8A15005B", Decimal: -1978335141, Symbol: "APPINSTALLER_CLI_ERROR_NESTEDINSTALLER_NOT_FOUND", Description: "Failed to find the nested installer in the archive."},
+	{Hex: "0x8A15005C", Decimal: -1978335140, Symbol: "APPINSTALLER_CLI_ERROR_EXTRACT_ARCHIVE_FAILED", Description: "Failed to extract archive."},
+	{Hex: "0x8A15005D", Decimal: -1978335139, Symbol: "APPINSTALLER_CLI_ERROR_NESTEDINSTALLER_INVALID_PATH", Description: "Invalid relative file path to nested installer provided."},
+	{Hex: "0x8A15005E", Decimal: -1978335138, Symbol: "APPINSTALLER_CLI_ERROR_PINNED_CERTIFICATE_MISMATCH", Description: "The server certificate did not match any of the expected values."},
+	{Hex: "0x8A15005F", Decimal: -1978335137, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_LOCATION_REQUIRED", Description: "Install location must be provided."},
+	{Hex: "0x8A150060", Decimal: -1978335136, Symbol: "APPINSTALLER_CLI_ERROR_ARCHIVE_SCAN_FAILED", Description: "Archive malware scan failed."},
+	{Hex: "0x8A150061", Decimal: -1978335135, Symbol: "APPINSTALLER_CLI_ERROR_PACKAGE_ALREADY_INSTALLED", Description: "Found at least one version of the package installed."},
+	{Hex: "0x8A150062", Decimal: -1978335134, Symbol: "APPINSTALLER_CLI_ERROR_PIN_ALREADY_EXISTS", Description: "A pin already exists for the package."},
+	{Hex: "0x8A150063", Decimal: -1978335133, Symbol: "APPINSTALLER_CLI_ERROR_PIN_DOES_NOT_EXIST", Description: "There is no pin for the package."},
+	{Hex: "0x8A150064", Decimal: -1978335132, Symbol: "APPINSTALLER_CLI_ERROR_CANNOT_OPEN_PINNING_INDEX", Description: "Unable to open the pin database."},
+	{Hex: "0x8A150065", Decimal: -1978335131, Symbol: "APPINSTALLER_CLI_ERROR_MULTIPLE_INSTALL_FAILED", Description: "One or more applications failed to install"},
+	{Hex: "0x8A150066", Decimal: -1978335130, Symbol: "APPINSTALLER_CLI_ERROR_MULTIPLE_UNINSTALL_FAILED", Description: "One or more applications failed to uninstall"},
+	{Hex: "0x8A150067", Decimal: -1978335129, Symbol: "APPINSTALLER_CLI_ERROR_NOT_ALL_QUERIES_FOUND_SINGLE", Description: "One or more queries did not return exactly one match"},
+	{Hex: "0x8A150068", Decimal: -1978335128, Symbol: "APPINSTALLER_CLI_ERROR_PACKAGE_IS_PINNED", Description: "The package has a pin that prevents upgrade."},
+	{Hex: "0x8A150069", Decimal: -1978335127, Symbol: "APPINSTALLER_CLI_ERROR_PACKAGE_IS_STUB", Description: "The package currently installed is the stub package"},
+	{Hex: "0x8A15006A", Decimal: -1978335126, Symbol: "APPINSTALLER_CLI_ERROR_APPTERMINATION_RECEIVED", Description: "Application shutdown signal received"},
+	{Hex: "0x8A15006B", Decimal: -1978335125, Symbol: "APPINSTALLER_CLI_ERROR_DOWNLOAD_DEPENDENCIES", Description: "Failed to download package dependencies."},
+	{Hex: "0x8A15006C", Decimal: -1978335124, Symbol: "APPINSTALLER_CLI_ERROR_DOWNLOAD_COMMAND_PROHIBITED", Description: "Failed to download package. Download for offline installation is prohibited."},
+	{Hex: "0x8A15006D", Decimal: -1978335123, Symbol: "APPINSTALLER_CLI_ERROR_SERVICE_UNAVAILABLE", Description: "A required service is busy or unavailable. Try again later."},
+	{Hex: "0x8A15006E", Decimal: -1978335122, Symbol: "APPINSTALLER_CLI_ERROR_RESUME_ID_NOT_FOUND", Description: "The guid provided does not correspond to a valid resume state."},
+	{Hex: "0x8A15006F", Decimal: -1978335121, Symbol: "APPINSTALLER_CLI_ERROR_CLIENT_VERSION_MISMATCH", Description: "The current client version did not match the client version of the saved state."},
+	{Hex: "0x8A150070", Decimal: -1978335120, Symbol: "APPINSTALLER_CLI_ERROR_INVALID_RESUME_STATE", Description: "The resume state data is invalid."},
+	{Hex: "0x8A150071", Decimal: -1978335119, Symbol: "APPINSTALLER_CLI_ERROR_CANNOT_OPEN_CHECKPOINT_INDEX", Description: "Unable to open the checkpoint database."},
+	{Hex: "0x8A150072", Decimal: -1978335118, Symbol: "APPINSTALLER_CLI_ERROR_RESUME_LIMIT_EXCEEDED", Description: "Exceeded max resume limit."},
+	{Hex: "0x8A150073", Decimal: -1978335117, Symbol: "APPINSTALLER_CLI_ERROR_INVALID_AUTHENTICATION_INFO", Description: "Invalid authentication info."},
+	{Hex: "0x8A150074", Decimal: -1978335116, Symbol: "APPINSTALLER_CLI_ERROR_AUTHENTICATION_TYPE_NOT_SUPPORTED", Description: "Authentication method not supported."},
+	{Hex: "0x8A150075", Decimal: -1978335115, Symbol: "APPINSTALLER_CLI_ERROR_AUTHENTICATION_FAILED", Description: "Authentication failed."},
+	{Hex: "0x8A150076", Decimal: -1978335114, Symbol: "APPINSTALLER_CLI_ERROR_AUTHENTICATION_INTERACTIVE_REQUIRED", Description: "Authentication failed. Interactive authentication required."},
+	{Hex: "0x8A150077", Decimal: -1978335113, Symbol: "APPINSTALLER_CLI_ERROR_AUTHENTICATION_CANCELLED_BY_USER", Description: "Authentication failed. User cancelled."},
+	{Hex: "0x8A150078", Decimal: -1978335112, Symbol: "APPINSTALLER_CLI_ERROR_AUTHENTICATION_INCORRECT_ACCOUNT", Description: "Authentication failed. Authenticated account is not the desired account."},
+	{Hex: "0x8A150079", Decimal: -1978335111, Symbol: "APPINSTALLER_CLI_ERROR_NO_REPAIR_INFO_FOUND", Description: "Repair command not found."},
+	{Hex: "0x8A15007A", Decimal: -1978335110, Symbol: "APPINSTALLER_CLI_ERROR_REPAIR_NOT_APPLICABLE", Description: "Repair operation is not applicable."},
+	{Hex: "0x8A15007B", Decimal: -1978335109, Symbol: "APPINSTALLER_CLI_ERROR_EXEC_REPAIR_FAILED", Description: "Repair operation failed."},
+	{Hex: "0x8A15007C", Decimal: -1978335108, Symbol: "APPINSTALLER_CLI_ERROR_REPAIR_NOT_SUPPORTED", Description: "The installer technology in use doesn't support repair."},
+	{Hex: "0x8A15007D", Decimal: -1978335107, Symbol: "APPINSTALLER_CLI_ERROR_ADMIN_CONTEXT_REPAIR_PROHIBITED", Description: "Repair operations involving administrator privileges are not permitted on packages installed within the user scope."},
+	{Hex: "0x8A15007E", Decimal: -1978335106, Symbol: "APPINSTALLER_CLI_ERROR_SQLITE_CONNECTION_TERMINATED", Description: "The SQLite connection was terminated to prevent corruption."},
+	{Hex: "0x8A15007F", Decimal: -1978335105, Symbol: "APPINSTALLER_CLI_ERROR_DISPLAYCATALOG_API_FAILED", Description: "Failed to get Microsoft Store package catalog."},
+	{Hex: "0x8A150080", Decimal: -1978335104, Symbol: "APPINSTALLER_CLI_ERROR_NO_APPLICABLE_DISPLAYCATALOG_PACKAGE", Description: "No applicable Microsoft Store package found from Microsoft Store package catalog."},
+	{Hex: "0x8A150081", Decimal: -1978335103, Symbol: "APPINSTALLER_CLI_ERROR_SFSCLIENT_API_FAILED", Description: "Failed to get Microsoft Store package download information."},
+	{Hex: "0x8A150082", Decimal: -1978335102, Symbol: "APPINSTALLER_CLI_ERROR_NO_APPLICABLE_SFSCLIENT_PACKAGE", Description: "No applicable Microsoft Store package download information found."},
+	{Hex: "0x8A150083", Decimal: -1978335101, Symbol: "APPINSTALLER_CLI_ERROR_LICENSING_API_FAILED", Description: "Failed to retrieve Microsoft Store package license."},
+	{Hex: "0x8A150084", Decimal: -1978335100, Symbol: "APPINSTALLER_CLI_ERROR_SFSCLIENT_PACKAGE_NOT_SUPPORTED", Description: "The Microsoft Store package does not support download command."},
+	{Hex: "0x8A150085", Decimal: -1978335099, Symbol: "APPINSTALLER_CLI_ERROR_LICENSING_API_FAILED_FORBIDDEN", Description: "Failed to retrieve Microsoft Store package license. The Microsoft Entra Id account does not have required privilege."},
+	{Hex: "0x8A150086", Decimal: -1978335098, Symbol: "APPINSTALLER_CLI_ERROR_INSTALLER_ZERO_BYTE_FILE", Description: "Downloaded zero byte installer; ensure that your network connection is working properly."},
+	{Hex: "0x8A150087", Decimal: -1978335097, Symbol: "APPINSTALLER_CLI_ERROR_FONT_INSTALL_FAILED", Description: "Failed installing one or more fonts."},
+	{Hex: "0x8A150088", Decimal: -1978335096, Symbol: "APPINSTALLER_CLI_ERROR_FONT_FILE_NOT_SUPPORTED", Description: "Font file is not supported and cannot be installed."},
+	{Hex: "0x8A150089", Decimal: -1978335095, Symbol: "APPINSTALLER_CLI_ERROR_FONT_ALREADY_INSTALLED", Description: "Font package is already installed."},
+	{Hex: "0x8A15008A", Decimal: -1978335094, Symbol: "APPINSTALLER_CLI_ERROR_FONT_FILE_NOT_FOUND", Description: "Font file not found."},
+	{Hex: "0x8A15008B", Decimal: -1978335093, Symbol: "APPINSTALLER_CLI_ERROR_FONT_UNINSTALL_FAILED", Description: "Font uninstall failed. The font may not be in a good state. Try uninstalling after a restart."},
+	{Hex: "0x8A15008C", Decimal: -1978335092, Symbol: "APPINSTALLER_CLI_ERROR_FONT_VALIDATION_FAILED", Description: "Font validation failed."},
+	{Hex: "0x8A15008D", Decimal: -1978335091, Symbol: "APPINSTALLER_CLI_ERROR_FONT_ROLLBACK_FAILED", Description: "Font rollback failed. The font may not be in a good state. Try uninstalling after a restart."},
+	{Hex: "0x8A150101", Decimal: -1978334975, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_PACKAGE_IN_USE", Description: "Application is currently running. Exit the application then try again."},
+	{Hex: "0x8A150102", Decimal: -1978334974, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_INSTALL_IN_PROGRESS", Description: "Another installation is already in progress. Try again later."},
+	{Hex: "0x8A150103", Decimal: -1978334973, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_FILE_IN_USE", Description: "One or more file is being used. Exit the application then try again."},
+	{Hex: "0x8A150104", Decimal: -1978334972, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_MISSING_DEPENDENCY", Description: "This package has a dependency missing from your system."},
+	{Hex: "0x8A150105", Decimal: -1978334971, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_DISK_FULL", Description: "There's no more space on your PC. Make space, then try again."},
+	{Hex: "0x8A150106", Decimal: -1978334970, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_INSUFFICIENT_MEMORY", Description: "There's not enough memory available to install. Close other applications then try again."},
+	{Hex: "0x8A150107", Decimal: -1978334969, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_NO_NETWORK", Description: "This application requires internet connectivity. Connect to a network then try again."},
+	{Hex: "0x8A150108", Decimal: -1978334968, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_CONTACT_SUPPORT", Description: "This application encountered an error during installation. Contact support."},
+	{Hex: "0x8A150109", Decimal: -1978334967, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_REBOOT_REQUIRED_TO_FINISH", Description: "Restart your PC to finish installation."},
+	{Hex: "0x8A15010A", Decimal: -1978334966, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_REBOOT_REQUIRED_FOR_INSTALL", Description: "Installation failed. Restart your PC then try again."},
+	{Hex: "0x8A15010B", Decimal: -1978334965, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_REBOOT_INITIATED", Description: "Your PC will restart to finish installation."},
+	{Hex: "0x8A15010C", Decimal: -1978334964, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_CANCELLED_BY_USER", Description: "You cancelled the installation."},
+	{Hex: "0x8A15010D", Decimal: -1978334963, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_ALREADY_INSTALLED", Description: "Another version of this application is already installed."},
+	{Hex: "0x8A15010E", Decimal: -1978334962, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_DOWNGRADE", Description: "A higher version of this application is already installed."},
+	{Hex: "0x8A15010F", Decimal: -1978334961, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_BLOCKED_BY_POLICY", Description: "Organization policies are preventing installation. Contact your admin."},
+	{Hex: "0x8A150110", Decimal: -1978334960, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_DEPENDENCIES", Description: "Failed to install package dependencies."},
+	{Hex: "0x8A150111", Decimal: -1978334959, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_PACKAGE_IN_USE_BY_APPLICATION", Description: "Application is currently in use by another application."},
+	{Hex: "0x8A150112", Decimal: -1978334958, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_INVALID_PARAMETER", Description: "Invalid parameter."},
+	{Hex: "0x8A150113", Decimal: -1978334957, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_SYSTEM_NOT_SUPPORTED", Description: "Package not supported by the system."},
+	{Hex: "0x8A150114", Decimal: -1978334956, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_UPGRADE_NOT_SUPPORTED", Description: "The installer does not support upgrading an existing package."},
+	{Hex: "0x8A150115", Decimal: -1978334955, Symbol: "APPINSTALLER_CLI_ERROR_INSTALL_CUSTOM_ERROR", Description: "Installation failed with installer custom error."},
+	{Hex: "0x8A150201", Decimal: -1978334719, Symbol: "WINGET_INSTALLED_STATUS_ARP_ENTRY_NOT_FOUND", Description: "The Apps and Features Entry for the package could not be found."},
+	{Hex: "0x8A150202", Decimal: -1978334718, Symbol: "WINGET_INSTALLED_STATUS_INSTALL_LOCATION_NOT_APPLICABLE", Description: "The install location is not applicable."},
+	{Hex: "0x8A150203", Decimal: -1978334717, Symbol: "WINGET_INSTALLED_STATUS_INSTALL_LOCATION_NOT_FOUND", Description: "The install location could not be found."},
+	{Hex: "0x8A150204", Decimal: -1978334716, Symbol: "WINGET_INSTALLED_STATUS_FILE_HASH_MISMATCH", Description: "The hash of the existing file did not match."},
+	{Hex: "0x8A150205", Decimal: -1978334715, Symbol: "WINGET_INSTALLED_STATUS_FILE_NOT_FOUND", Description: "File not found."},
+	{Hex: "0x8A150206", Decimal: -1978334714, Symbol: "WINGET_INSTALLED_STATUS_FILE_FOUND_WITHOUT_HASH_CHECK", Description: "The file was found but the hash was not checked."},
+	{Hex: "0x8A150207", Decimal: -1978334713, Symbol: "WINGET_INSTALLED_STATUS_FILE_ACCESS_ERROR", Description: "The file could not be accessed."},
+	{Hex: "0x8A15C001", Decimal: -1978286079, Symbol: "WINGET_CONFIG_ERROR_INVALID_CONFIGURATION_FILE", Description: "The configuration file is invalid."},
+	{Hex: "0x8A15C002", Decimal: -1978286078, Symbol: "WINGET_CONFIG_ERROR_INVALID_YAML", Description: "The YAML syntax is invalid."},
+	{Hex: "0x8A15C003", Decimal: -1978286077, Symbol: "WINGET_CONFIG_ERROR_INVALID_FIELD_TYPE", Description: "A configuration field has an invalid type."},
+	{Hex: "0x8A15C004", Decimal: -1978286076, Symbol: "WINGET_CONFIG_ERROR_UNKNOWN_CONFIGURATION_FILE_VERSION", Description: "The configuration has an unknown version."},
+	{Hex: "0x8A15C005", Decimal: -1978286075, Symbol: "WINGET_CONFIG_ERROR_SET_APPLY_FAILED", Description: "An error occurred while applying the configuration."},
+	{Hex: "0x8A15C006", Decimal: -1978286074, Symbol: "WINGET_CONFIG_ERROR_DUPLICATE_IDENTIFIER", Description: "The configuration contains a duplicate identifier."},
+	{Hex: "0x8A15C007", Decimal: -1978286073, Symbol: "WINGET_CONFIG_ERROR_MISSING_DEPENDENCY", Description: "The configuration is missing a dependency."},
+	{Hex: "0x8A15C008", Decimal: -1978286072, Symbol: "WINGET_CONFIG_ERROR_DEPENDENCY_UNSATISFIED", Description: "The configuration has an unsatisfied dependency."},
+	{Hex: "0x8A15C009", Decimal: -1978286071, Symbol: "WINGET_CONFIG_ERROR_ASSERTION_FAILED", Description: "An assertion for the configuration unit failed."},
+	{Hex: "0x8A15C00A", Decimal: -1978286070, Symbol: "WINGET_CONFIG_ERROR_MANUALLY_SKIPPED", Description: "The configuration was manually skipped."},
+	{Hex: "0x8A15C00B", Decimal: -1978286069, Symbol: "WINGET_CONFIG_ERROR_WARNING_NOT_ACCEPTED", Description: "A warning was thrown and the user declined to continue execution."},
+	{Hex: "0x8A15C00C", Decimal: -1978286068, Symbol: "WINGET_CONFIG_ERROR_SET_DEPENDENCY_CYCLE", Description: "The dependency graph contains a cycle which cannot be resolved."},
+	{Hex: "0x8A15C00D", Decimal: -1978286067, Symbol: "WINGET_CONFIG_ERROR_INVALID_FIELD_VALUE", Description: "The configuration has an invalid field value."},
+	{Hex: "0x8A15C00E", Decimal: -1978286066, Symbol: "WINGET_CONFIG_ERROR_MISSING_FIELD", Description: "The configuration is missing a field."},
+	{Hex: "0x8A15C00F", Decimal: -1978286065, Symbol: "WINGET_CONFIG_ERROR_TEST_FAILED", Description: "Some of the configuration units failed while testing their state."},
+	{Hex: "0x8A15C010", Decimal: -1978286064, Symbol: "WINGET_CONFIG_ERROR_TEST_NOT_RUN", Description: "Configuration state was not tested."},
+	{Hex: "0x8A15C011", Decimal: -1978286063, Symbol: "WINGET_CONFIG_ERROR_GET_FAILED", Description: "The configuration unit failed getting its properties."},
+	{Hex: "0x8A15C012", Decimal: -1978286062, Symbol: "WINGET_CONFIG_ERROR_HISTORY_ITEM_NOT_FOUND", Description: "The specified configuration could not be found."},
+	{Hex: "0x8A15C013", Decimal: -1978286061, Symbol: "WINGET_CONFIG_ERROR_PARAMETER_INTEGRITY_BOUNDARY", Description: "Parameter cannot be passed across integrity boundary."},
+	{Hex: "0x8A15C101", Decimal: -1978285823, Symbol: "WINGET_CONFIG_ERROR_UNIT_NOT_INSTALLED", Description: "The configuration unit was not installed."},
+	{Hex: "0x8A15C102", Decimal: -1978285822, Symbol: "WINGET_CONFIG_ERROR_UNIT_NOT_FOUND_REPOSITORY", Description: "The configuration unit could not be found."},
+	{Hex: "0x8A15C103", Decimal: -1978285821, Symbol: "WINGET_CONFIG_ERROR_UNIT_MULTIPLE_MATCHES", Description: "Multiple matches were found for the configuration unit specify the module to select the correct one."},
+	{Hex: "0x8A15C104", Decimal: -1978285820, Symbol: "WINGET_CONFIG_ERROR_UNIT_INVOKE_GET", Description: "The configuration unit failed while attempting to get the current system state."},
+	{Hex: "0x8A15C105", Decimal: -1978285819, Symbol: "WINGET_CONFIG_ERROR_UNIT_INVOKE_TEST", Description: "The configuration unit failed while attempting to test the current system state."},
+	{Hex: "0x8A15C106", Decimal: -1978285818, Symbol: "WINGET_CONFIG_ERROR_UNIT_INVOKE_SET", Description: "The configuration unit failed while attempting to apply the desired state."},
+	{Hex: "0x8A15C107", Decimal: -1978285817, Symbol: "WINGET_CONFIG_ERROR_UNIT_MODULE_CONFLICT", Description: "The module for the configuration unit is available in multiple locations with the same version."},
+	{Hex: "0x8A15C108", Decimal: -1978285816, Symbol: "WINGET_CONFIG_ERROR_UNIT_IMPORT_MODULE", Description: "Loading the module for the configuration unit failed."},
+	{Hex: "0x8A15C109", Decimal: -1978285815, Symbol: "WINGET_CONFIG_ERROR_UNIT_INVOKE_INVALID_RESULT", Description: "The configuration unit returned an unexpected result during execution."},
+	{Hex: "0x8A15C110", Decimal: -1978285808, Symbol: "WINGET_CONFIG_ERROR_UNIT_SETTING_CONFIG_ROOT", Description: "A unit contains a setting that requires the config root."},
+	{Hex: "0x8A15C111", Decimal: -1978285807, Symbol: "WINGET_CONFIG_ERROR_UNIT_IMPORT_MODULE_ADMIN", Description: "Loading the module for the configuration unit failed because it requires administrator privileges to run."},
+	{Hex: "0x8A15C112", Decimal: -1978285806, Symbol: "WINGET_CONFIG_ERROR_NOT_SUPPORTED_BY_PROCESSOR", Description: "Operation is not supported by the configuration processor."},
+}
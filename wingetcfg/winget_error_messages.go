@@ -0,0 +1,127 @@
+package wingetcfg
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the locale of the messages in ErrorCodes, used when a message is not translated
+const DefaultLocale = "en"
+
+// Message catalogs bundled with the library, one JSON object per locale mapping codes to messages
+//
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+var (
+	messageCatalogs     = map[string]map[string]string{}
+	messageCatalogsLock sync.RWMutex
+	messageCatalogsOnce sync.Once
+)
+
+// loadEmbeddedMessageCatalogs loads the bundled catalogs, the locale is the name of the file. It panics
+// if a catalog is not valid, they're embedded so it can only fail with a broken build.
+func loadEmbeddedMessageCatalogs() {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic("wingetcfg: could not read the embedded message catalogs: " + err.Error())
+	}
+
+	for _, entry := range entries {
+		data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic("wingetcfg: could not read the embedded message catalog " + entry.Name() + ": " + err.Error())
+		}
+		if err := loadMessageCatalog(strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())), bytes.NewReader(data)); err != nil {
+			panic("wingetcfg: embedded message catalog " + entry.Name() + " is not valid: " + err.Error())
+		}
+	}
+}
+
+// LoadMessageCatalog loads a message catalog for a locale from a JSON object that maps
+// error codes (e.g. 0x8A150014) to messages. Messages are merged with the ones already
+// loaded for the locale, so bundled translations can be completed or overridden. Nothing is
+// loaded if any of the codes is not valid.
+func LoadMessageCatalog(locale string, r io.Reader) error {
+	messageCatalogsOnce.Do(loadEmbeddedMessageCatalogs)
+	return loadMessageCatalog(locale, r)
+}
+
+func loadMessageCatalog(locale string, r io.Reader) error {
+	messages := map[string]string{}
+	if err := json.NewDecoder(r).Decode(&messages); err != nil {
+		return fmt.Errorf("could not parse message catalog for locale %s: %v", locale, err)
+	}
+
+	locale = normalizeLocale(locale)
+
+	// All the codes are validated before the catalog is changed
+	validated := map[string]string{}
+	for code, message := range messages {
+		e, err := ParseError(code)
+		if err != nil {
+			return fmt.Errorf("message catalog for locale %s: %v", locale, err)
+		}
+		validated[e.Hex()] = message
+	}
+
+	messageCatalogsLock.Lock()
+	defer messageCatalogsLock.Unlock()
+
+	catalog, ok := messageCatalogs[locale]
+	if !ok {
+		catalog = map[string]string{}
+		messageCatalogs[locale] = catalog
+	}
+	for code, message := range validated {
+		catalog[code] = message
+	}
+
+	return nil
+}
+
+// Lookup returns the message for a WinGet error code in the requested locale, e.g. tr, de or de-DE.
+// The code can be given in any of the forms accepted by ParseError. If there's no message for the
+// locale, the message for its language (de for de-DE) is used and finally the English message.
+// An empty string is returned for unknown codes.
+func Lookup(code string, locale string) string {
+	e, err := ParseError(code)
+	if err != nil {
+		return ""
+	}
+
+	messageCatalogsOnce.Do(loadEmbeddedMessageCatalogs)
+
+	locale = normalizeLocale(locale)
+	language, _, _ := strings.Cut(locale, "-")
+
+	messageCatalogsLock.RLock()
+	defer messageCatalogsLock.RUnlock()
+
+	for _, l := range []string{locale, language} {
+		if message, ok := messageCatalogs[l][e.Hex()]; ok {
+			return message
+		}
+	}
+
+	return ErrorCodes[e.Hex()]
+}
+
+// LocalizedMessage returns the description of the error in the requested locale, see Lookup
+func (e *Error) LocalizedMessage(locale string) string {
+	if message := Lookup(e.Hex(), locale); message != "" {
+		return message
+	}
+	return e.Message()
+}
+
+// normalizeLocale converts locales like de_DE or DE-de to de-de
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
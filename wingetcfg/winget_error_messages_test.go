@@ -0,0 +1,36 @@
+package wingetcfg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedMessageCatalogs(t *testing.T) {
+	for locale, want := range map[string]string{
+		"de-DE": "Keine Pakete gefunden",
+		"de":    "Keine Pakete gefunden",
+		"tr_TR": "Paket bulunamadı",
+		"fr-FR": "No packages found",
+	} {
+		if got := Lookup("0x8A150014", locale); got != want {
+			t.Errorf("%s: got %q, want %q", locale, got, want)
+		}
+	}
+}
+
+func TestLoadMessageCatalogIsAtomic(t *testing.T) {
+	catalog := `{"0x8A150014": "Aucun package trouvé", "not a code": "invalid"}`
+	if err := LoadMessageCatalog("fr-CA", strings.NewReader(catalog)); err == nil {
+		t.Fatal("a catalog with an invalid code should be rejected")
+	}
+	if got := Lookup("0x8A150014", "fr-CA"); got != "No packages found" {
+		t.Errorf("nothing should be loaded from an invalid catalog, got %q", got)
+	}
+
+	if err := LoadMessageCatalog("fr-CA", strings.NewReader(`{"0x8A150014": "Aucun package trouvé"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if got := Lookup("0x8A150014", "fr-CA"); got != "Aucun package trouvé" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,93 @@
+package wingetcfg
+
+// Remediation tells what can be done after a WinGet failure
+type Remediation string
+
+const (
+	// RemediationRetry means the failure is transient and the operation can be retried later
+	RemediationRetry Remediation = "retry"
+	// RemediationReboot means the device must be restarted before the operation completes or can be retried
+	RemediationReboot Remediation = "reboot"
+	// RemediationUserAction means someone must act on the device or accept something before retrying
+	RemediationUserAction Remediation = "user-action"
+	// RemediationPermanent means retrying won't help without changing the configuration
+	RemediationPermanent Remediation = "permanent"
+)
+
+// Install-time errors reported by the installers
+var (
+	ErrInstallPackageInUse        = NewError(uint32(0x8A150101))
+	ErrInstallInProgress          = NewError(uint32(0x8A150102))
+	ErrInstallFileInUse           = NewError(uint32(0x8A150103))
+	ErrInstallMissingDependency   = NewError(uint32(0x8A150104))
+	ErrInstallDiskFull            = NewError(uint32(0x8A150105))
+	ErrInstallInsufficientMemory  = NewError(uint32(0x8A150106))
+	ErrInstallNoNetwork           = NewError(uint32(0x8A150107))
+	ErrInstallContactSupport      = NewError(uint32(0x8A150108))
+	ErrInstallRebootRequired      = NewError(uint32(0x8A150109))
+	ErrInstallRebootBeforeInstall = NewError(uint32(0x8A15010A))
+	ErrInstallRebootInitiated     = NewError(uint32(0x8A15010B))
+	ErrInstallCancelledByUser     = NewError(uint32(0x8A15010C))
+	ErrInstallAlreadyInstalled    = NewError(uint32(0x8A15010D))
+	ErrInstallDowngrade           = NewError(uint32(0x8A15010E))
+	ErrInstallBlockedByPolicy     = NewError(uint32(0x8A15010F))
+	ErrInstallDependencies        = NewError(uint32(0x8A150110))
+	ErrInstallPackageInUseByApp   = NewError(uint32(0x8A150111))
+	ErrInstallInvalidParameter    = NewError(uint32(0x8A150112))
+	ErrInstallSystemNotSupported  = NewError(uint32(0x8A150113))
+	ErrInstallUpgradeNotSupported = NewError(uint32(0x8A150114))
+	ErrInstallCustomError         = NewError(uint32(0x8A150115))
+)
+
+// Codes that are not permanent failures, any other code is considered permanent
+var errorCodeRemediations = map[uint32]Remediation{
+	// Transient conditions
+	0x8A150005: RemediationRetry, // Cancellation signal received
+	0x8A150008: RemediationRetry, // Downloading installer failed
+	0x8A15002E: RemediationRetry, // Download size does not match expected content length
+	0x8A15003B: RemediationRetry, // Rest API internal error
+	0x8A150040: RemediationRetry, // Error reading from the stream
+	0x8A150045: RemediationRetry, // Failed to open the source
+	0x8A15004B: RemediationRetry, // Failed to open one or more sources
+	0x8A15006A: RemediationRetry, // Application shutdown signal received
+	0x8A15006D: RemediationRetry, // A required service is busy or unavailable
+	0x8A150086: RemediationRetry, // Downloaded zero byte installer
+	0x8A150101: RemediationRetry, // Application is currently running
+	0x8A150102: RemediationRetry, // Another installation is already in progress
+	0x8A150103: RemediationRetry, // One or more file is being used
+	0x8A150106: RemediationRetry, // Not enough memory available to install
+	0x8A150107: RemediationRetry, // The application requires internet connectivity
+	0x8A150111: RemediationRetry, // Application is currently in use by another application
+
+	// Restart required
+	0x8A150109: RemediationReboot, // Restart your PC to finish installation
+	0x8A15010A: RemediationReboot, // Installation failed. Restart your PC then try again
+	0x8A15010B: RemediationReboot, // Your PC will restart to finish installation
+
+	// Someone has to act on the device
+	0x8A150019: RemediationUserAction, // Command requires administrator privileges to run
+	0x8A150041: RemediationUserAction, // Package agreements were not agreed to
+	0x8A150046: RemediationUserAction, // Source agreements were not agreed to
+	0x8A150076: RemediationUserAction, // Interactive authentication required
+	0x8A150077: RemediationUserAction, // Authentication cancelled by the user
+	0x8A150105: RemediationUserAction, // There's no more space on your PC
+	0x8A15010C: RemediationUserAction, // You cancelled the installation
+}
+
+// Remediation classifies the error telling whether it's retryable, needs a reboot,
+// needs user action or is permanent
+func (e *Error) Remediation() Remediation {
+	if remediation, ok := errorCodeRemediations[e.code]; ok {
+		return remediation
+	}
+	return RemediationPermanent
+}
+
+// ClassifyExitCode classifies the exit code returned by a winget process. It returns false
+// if the exit code is 0 as there's nothing to remediate.
+func ClassifyExitCode(exitCode int) (Remediation, bool) {
+	if exitCode == 0 {
+		return "", false
+	}
+	return NewError(exitCode).Remediation(), true
+}
@@ -0,0 +1,241 @@
+package wingetcfg
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+const (
+	WinGetFileResource       = "FileSystemDsc/FileSystemObject"
+	WinGetRemoteFileResource = "xPSDesiredStateConfiguration/xRemoteFile"
+)
+
+const (
+	FileTypeFile      string = "File"
+	FileTypeDirectory string = "Directory"
+)
+
+// Checksums to compare files with their source, besides the FileHash constants
+const (
+	FileChecksumCreatedDate  string = "CreatedDate"
+	FileChecksumModifiedDate string = "ModifiedDate"
+)
+
+// fileChecksums maps the FileHash constants to the checksum names used by xArchive
+var fileChecksums = map[string]string{
+	FileHashSHA1:             "SHA-1",
+	FileHashSHA256:           "SHA-256",
+	FileHashSHA512:           "SHA-512",
+	FileChecksumCreatedDate:  FileChecksumCreatedDate,
+	FileChecksumModifiedDate: FileChecksumModifiedDate,
+}
+
+// fileObjectChecksums maps the FileHash constants to the checksum names used by FileSystemObject
+var fileObjectChecksums = map[string]string{
+	FileHashMD5:              "md5",
+	FileChecksumCreatedDate:  "ctime",
+	FileChecksumModifiedDate: "mtime",
+}
+
+// fileHashLengths is the length of the hexadecimal hash of each algorithm
+var fileHashLengths = map[string]int{
+	FileHashMD5:       32,
+	FileHashRIPEMD160: 40,
+	FileHashSHA1:      40,
+	FileHashSHA256:    64,
+	FileHashSHA384:    96,
+	FileHashSHA512:    128,
+}
+
+// CopyFile copies a file, or a directory and its content, keeping the destination in sync with the source.
+// ID is an optional identifier.
+// SourcePath is required and is the path to copy from.
+// DestinationPath is required and is the path to copy to.
+// FileType is File or Directory.
+func CopyFile(ID, sourcePath string, destinationPath string, fileType string) (*WinGetResource, error) {
+	return NewFileResource(ID, "", destinationPath, sourcePath, "", fileType, fileType == FileTypeDirectory, true, FileHashMD5, EnsurePresent)
+}
+
+// WriteFile creates a text file with the given contents, the file is replaced if its contents differ.
+// ID is an optional identifier.
+// DestinationPath is required and is the path of the file.
+// Contents is the text of the file.
+func WriteFile(ID, destinationPath string, contents string) (*WinGetResource, error) {
+	return NewFileResource(ID, "", destinationPath, "", contents, FileTypeFile, false, true, "", EnsurePresent)
+}
+
+// CreateDirectory creates a directory if it doesn't exist.
+// ID is an optional identifier.
+// DestinationPath is required and is the path of the directory.
+func CreateDirectory(ID, destinationPath string) (*WinGetResource, error) {
+	return NewFileResource(ID, "", destinationPath, "", "", FileTypeDirectory, false, false, "", EnsurePresent)
+}
+
+// RemoveFile removes a file, or a directory and its content.
+// ID is an optional identifier.
+// DestinationPath is required and is the path to remove.
+// FileType is File or Directory.
+func RemoveFile(ID, destinationPath string, fileType string) (*WinGetResource, error) {
+	return NewFileResource(ID, "", destinationPath, "", "", fileType, fileType == FileTypeDirectory, true, "", EnsureAbsent)
+}
+
+// NewFileResource creates a new WinGetResource that contains the settings to manage a file or directory.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// DestinationPath is required and is the path of the file or directory to manage.
+// SourcePath is an optional path to copy the file or directory from.
+// Contents is the text of the file, it cannot be used with SourcePath or for directories.
+// FileType is File or Directory, if empty the resource's default, File, is used.
+// Recurse specifies whether the content of the directories is managed too, it's only valid for directories.
+// Force overwrites the destination if it exists and allows removing directories that are not empty.
+// Checksum is how the destination is compared with the source: FileHashMD5, FileChecksumCreatedDate or
+// FileChecksumModifiedDate. If empty the resource's default, MD5, is used.
+// Ensure specifies whether the file or directory should exist.
+// Reference: https://github.com/dsccommunity/FileSystemDsc
+func NewFileResource(ID, description string, destinationPath string, sourcePath string, contents string, fileType string, recurse bool, force bool, checksum string, ensure string) (*WinGetResource, error) {
+	r := WinGetResource{}
+	r.Resource = WinGetFileResource
+
+	// ID (optional)
+	if ID != "" {
+		r.ID = ID
+	}
+
+	// Directives
+	r.Directives.Description = description
+	r.Directives.AllowPreRelease = true
+
+	// Settings
+	r.Settings = map[string]any{}
+
+	if destinationPath == "" {
+		return nil, errors.New("destinationPath cannot be empty")
+	}
+	r.Settings["DestinationPath"] = destinationPath
+
+	switch fileType {
+	case "":
+		fileType = FileTypeFile
+	case FileTypeFile, FileTypeDirectory:
+		r.Settings["Type"] = fileType
+	default:
+		return nil, fmt.Errorf("file type %s is not valid, use File or Directory", fileType)
+	}
+
+	if sourcePath != "" {
+		r.Settings["SourcePath"] = sourcePath
+	}
+
+	if contents != "" {
+		if sourcePath != "" {
+			return nil, errors.New("contents and sourcePath cannot be used together")
+		}
+		if fileType == FileTypeDirectory {
+			return nil, errors.New("contents cannot be set for a directory")
+		}
+		r.Settings["Contents"] = contents
+	}
+
+	if recurse {
+		if fileType != FileTypeDirectory {
+			return nil, errors.New("recurse is only valid for directories")
+		}
+		r.Settings["Recurse"] = true
+	}
+
+	r.Settings["Force"] = force
+
+	if checksum != "" {
+		name, ok := fileObjectChecksums[checksum]
+		if !ok {
+			return nil, fmt.Errorf("checksum %s is not valid, use MD5, CreatedDate or ModifiedDate", checksum)
+		}
+		if sourcePath == "" {
+			return nil, errors.New("checksum requires a sourcePath to compare with")
+		}
+		r.Settings["Checksum"] = name
+	}
+
+	r.Settings["Ensure"] = SetEnsureValue(ensure)
+
+	return &r, nil
+}
+
+// DownloadFile downloads a file, e.g. an installer to be used later with InstallMSIPackage.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// Uri is required and is the HTTP or HTTPS address of the file.
+// DestinationPath is required and is the path where the file is saved.
+// ChecksumType and Checksum are optional, see NewRemoteFileResource.
+func DownloadFile(ID string, description string, uri string, destinationPath string, checksumType string, checksum string) (*WinGetResource, error) {
+	return NewRemoteFileResource(ID, description, uri, destinationPath, nil, checksumType, checksum)
+}
+
+// NewRemoteFileResource creates a new WinGetResource that contains the settings to download a file.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// Uri is required and is the HTTP or HTTPS address of the file.
+// DestinationPath is required and is the path where the file is saved.
+// Headers are optional HTTP headers sent with the request, e.g. Authorization.
+// ChecksumType is the algorithm of the checksum: FileHashMD5, FileHashRIPEMD160, FileHashSHA1,
+// FileHashSHA256, FileHashSHA384 or FileHashSHA512. It's required if Checksum is set.
+// Checksum is the expected hash of the file as an hexadecimal string, the file is downloaded again if it doesn't match.
+// Reference: https://github.com/dsccommunity/xPSDesiredStateConfiguration/blob/main/source/DSCResources/DSC_xRemoteFile/DSC_xRemoteFile.psm1
+func NewRemoteFileResource(ID string, description string, uri string, destinationPath string, headers map[string]string, checksumType string, checksum string) (*WinGetResource, error) {
+	r := WinGetResource{}
+	r.Resource = WinGetRemoteFileResource
+
+	// ID (optional)
+	if ID != "" {
+		r.ID = ID
+	}
+
+	// Directives
+	r.Directives.Description = description
+	r.Directives.AllowPreRelease = true
+
+	// Settings
+	r.Settings = map[string]any{}
+
+	if uri == "" {
+		return nil, errors.New("uri cannot be empty")
+	}
+	u, err := url.Parse(uri)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "file") || (u.Host == "" && u.Scheme != "file") {
+		return nil, fmt.Errorf("uri %s is not a valid HTTP, HTTPS or file address", uri)
+	}
+	r.Settings["Uri"] = uri
+
+	if destinationPath == "" {
+		return nil, errors.New("destinationPath cannot be empty")
+	}
+	r.Settings["DestinationPath"] = destinationPath
+
+	if len(headers) > 0 {
+		h := map[string]any{}
+		for name, value := range headers {
+			if name == "" {
+				return nil, errors.New("headers cannot have empty names")
+			}
+			h[name] = value
+		}
+		r.Settings["Headers"] = h
+	}
+
+	if checksum != "" {
+		length, ok := fileHashLengths[checksumType]
+		if !ok {
+			return nil, fmt.Errorf("checksum type %s is not valid, use MD5, RIPEMD160, SHA1, SHA256, SHA384 or SHA512", checksumType)
+		}
+		if len(checksum) != length || !isHexString(checksum) {
+			return nil, fmt.Errorf("checksum %s is not a valid %s hash", checksum, checksumType)
+		}
+		r.Settings["ChecksumType"] = checksumType
+		r.Settings["Checksum"] = checksum
+	} else if checksumType != "" {
+		return nil, errors.New("checksum cannot be empty if checksumType is set")
+	}
+
+	return &r, nil
+}
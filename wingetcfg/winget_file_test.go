@@ -0,0 +1,23 @@
+package wingetcfg
+
+import "testing"
+
+func TestCopyFile(t *testing.T) {
+	r, err := CopyFile("config", `\\server\share\config`, `C:\ProgramData\Contoso`, FileTypeDirectory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Resource != WinGetFileResource || WinGetFileResource != "FileSystemDsc/FileSystemObject" {
+		t.Errorf("got resource %s", r.Resource)
+	}
+	if r.Settings["Recurse"] != true || r.Settings["Force"] != true || r.Settings["Checksum"] != "md5" {
+		t.Errorf("unexpected settings %v", r.Settings)
+	}
+
+	if _, err := NewFileResource("", "", `C:\Temp\a.txt`, `C:\a.txt`, "", FileTypeFile, false, false, FileHashSHA256, EnsurePresent); err == nil {
+		t.Error("FileSystemObject doesn't support SHA256 checksums")
+	}
+	if _, err := NewFileResource("", "", `C:\Temp\a.txt`, "", "", FileTypeFile, false, false, FileChecksumModifiedDate, EnsurePresent); err == nil {
+		t.Error("a checksum without sourcePath should be rejected")
+	}
+}
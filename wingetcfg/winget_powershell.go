@@ -1,20 +1,129 @@
 package wingetcfg
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
 const (
 	scnorionplusPowershell = "scnorionplus/Powershell"
+	PSDscScriptResource    = "PSDscResources/Script"
+	XScriptResource        = "xPSDesiredStateConfiguration/xScript"
+)
+
+// ScriptRunMode tells the agent when a script created with ExecutePowershellScript must be run
+type ScriptRunMode string
+
+const (
+	// ScriptRunOnce runs the script the first time the agent applies the configuration, later runs are skipped
+	ScriptRunOnce ScriptRunMode = "once"
+	// ScriptRunAlways runs the script every time the agent applies the configuration
+	ScriptRunAlways ScriptRunMode = "always"
+	// ScriptRunOnChange runs the script when its content differs from the one the agent ran last time
+	ScriptRunOnChange ScriptRunMode = "on-change"
+	// ScriptRunScheduled runs the script following the schedule configured in the agent
+	ScriptRunScheduled ScriptRunMode = "scheduled"
 )
 
 // As there's no specific Powershell DSC that runs powershell scripts we create a custom resource
-func ExecutePowershellScript(id string, name string, pwshell string, run string) (*WinGetResource, error) {
+// that the agent runs by itself. The resource has the following settings:
+//   - ID identifies the script. The agent stores the last run of each ID, so it must be unique and stable
+//     across configurations for once and on-change scripts to work.
+//   - Name is a friendly name used in logs and reports.
+//   - Script is the PowerShell code.
+//   - ScriptRun is one of the ScriptRunMode values. For on-change the agent compares the SHA-256 of the
+//     script with the one of its last run.
+//   - ScriptHash, ScriptHashAlgorithm and ScriptSigner are optional, see AddScriptHash and SignScript.
+//
+// Agents must fail the resource when ScriptRun has an unknown value instead of running the script.
+func ExecutePowershellScript(id string, name string, pwshell string, run ScriptRunMode) (*WinGetResource, error) {
 	r := WinGetResource{}
 	r.Resource = scnorionplusPowershell
 
+	if id == "" {
+		return nil, errors.New("id cannot be empty")
+	}
+
+	if strings.TrimSpace(pwshell) == "" {
+		return nil, errors.New("script cannot be empty")
+	}
+
+	switch run {
+	case ScriptRunOnce, ScriptRunAlways, ScriptRunOnChange, ScriptRunScheduled:
+	default:
+		return nil, fmt.Errorf("script run mode %s is not valid, use once, always, on-change or scheduled", run)
+	}
+
 	// Settings
 	r.Settings = map[string]any{}
 	r.Settings["ID"] = id
 	r.Settings["Script"] = pwshell
-	r.Settings["ScriptRun"] = run
+	r.Settings["ScriptRun"] = string(run)
 	r.Settings["Name"] = name
 
 	return &r, nil
 }
+
+// RunPowershellScript creates a PSDscResources/Script resource that runs the script every time the configuration
+// is applied, as the test script always returns false. Unlike ExecutePowershellScript, it works with stock winget configure.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// Script is the PowerShell code to run.
+func RunPowershellScript(ID string, description string, script string) (*WinGetResource, error) {
+	return NewScriptResource(ID, description, PSDscScriptResource, "@{ Result = '' }", "return $false", script)
+}
+
+// NewScriptResource creates a new WinGetResource that runs PowerShell code using a standard DSC script resource.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// Resource is the DSC resource to use, PSDscResources/Script or xPSDesiredStateConfiguration/xScript.
+// GetScript is the PowerShell code that returns the current state as a hashtable with a Result key.
+// TestScript is the PowerShell code that returns true if the system is in the desired state, if it
+// returns false the SetScript is run.
+// SetScript is the PowerShell code that brings the system to the desired state.
+// Reference: https://github.com/PowerShell/PSDscResources/blob/dev/DscResources/MSFT_ScriptResource/MSFT_ScriptResource.psm1
+func NewScriptResource(ID string, description string, resource string, getScript string, testScript string, setScript string) (*WinGetResource, error) {
+	r := WinGetResource{}
+
+	switch resource {
+	case PSDscScriptResource, XScriptResource:
+		r.Resource = resource
+	default:
+		return nil, fmt.Errorf("resource %s is not a supported script resource", resource)
+	}
+
+	// ID (optional)
+	if ID != "" {
+		r.ID = ID
+	}
+
+	// Directives
+	r.Directives.Description = description
+	r.Directives.AllowPreRelease = true
+
+	// Settings
+	r.Settings = map[string]any{}
+
+	if getScript == "" {
+		return nil, errors.New("getScript cannot be empty")
+	}
+	r.Settings["GetScript"] = getScript
+
+	if testScript == "" {
+		return nil, errors.New("testScript cannot be empty")
+	}
+	r.Settings["TestScript"] = testScript
+
+	if setScript == "" {
+		return nil, errors.New("setScript cannot be empty")
+	}
+	r.Settings["SetScript"] = setScript
+
+	return &r, nil
+}
+
+// powershellQuote returns s as a PowerShell single quoted string
+func powershellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
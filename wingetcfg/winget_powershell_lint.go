@@ -0,0 +1,287 @@
+package wingetcfg
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+type ScriptSeverity string
+
+const (
+	ScriptSeverityError   ScriptSeverity = "error"
+	ScriptSeverityWarning ScriptSeverity = "warning"
+)
+
+// ScriptSyntaxRule is the rule name used for unbalanced quotes, braces, here-strings and comments
+const ScriptSyntaxRule = "syntax"
+
+// ScriptRule flags the PowerShell code that matches its pattern. Comments are ignored
+// when the rules are evaluated so commented code is never flagged.
+type ScriptRule struct {
+	Name     string
+	Severity ScriptSeverity
+	Message  string
+	Pattern  *regexp.Regexp
+}
+
+// ScriptFinding is a problem found in a PowerShell script, lines and columns start at 1
+type ScriptFinding struct {
+	Rule     string
+	Severity ScriptSeverity
+	Message  string
+	Line     int
+	Column   int
+}
+
+// DefaultScriptRules flags patterns commonly used to run downloaded code, hide commands or weaken the endpoint protection
+var DefaultScriptRules = []ScriptRule{
+	{
+		Name:     "invoke-expression-download",
+		Severity: ScriptSeverityError,
+		Message:  "Invoke-Expression runs content downloaded from the network",
+		Pattern:  regexp.MustCompile(`(?i)(\bInvoke-Expression\b|\biex\b)[^\n]*(DownloadString|DownloadData|Invoke-WebRequest|\biwr\b|Invoke-RestMethod|\birm\b|Net\.WebClient)|(DownloadString|Invoke-WebRequest|\biwr\b|Invoke-RestMethod|\birm\b)[^\n]*\|\s*(Invoke-Expression|iex)\b`),
+	},
+	{
+		Name:     "encoded-command",
+		Severity: ScriptSeverityError,
+		Message:  "PowerShell is started with an encoded command that hides the code that is run",
+		Pattern:  regexp.MustCompile(`(?i)\b(powershell|pwsh)(\.exe)?\b[^\n|;]*[\s'"]-(e(n(c(o(d(e(d(c(o(m(m(a(n(d)?)?)?)?)?)?)?)?)?)?)?)?)?|ec)\s+['"]?[A-Za-z0-9+/]{16,}={0,2}`),
+	},
+	{
+		Name:     "disable-defender",
+		Severity: ScriptSeverityError,
+		Message:  "Microsoft Defender protection is disabled",
+		Pattern:  regexp.MustCompile(`(?i)Set-MpPreference[^\n]*-Disable(RealtimeMonitoring|BehaviorMonitoring|IOAVProtection|ScriptScanning|IntrusionPreventionSystem|BlockAtFirstSeen|ArchiveScanning)\s+(\$true|1)|DisableAntiSpyware|DisableAntiVirus|(Stop-Service|Set-Service)[^\n]*\bWinDefend\b`),
+	},
+	{
+		Name:     "defender-exclusion",
+		Severity: ScriptSeverityWarning,
+		Message:  "An exclusion is added to Microsoft Defender",
+		Pattern:  regexp.MustCompile(`(?i)Add-MpPreference[^\n]*-Exclusion(Path|Process|Extension|IpAddress)`),
+	},
+	{
+		Name:     "amsi-bypass",
+		Severity: ScriptSeverityError,
+		Message:  "The Antimalware Scan Interface is tampered with",
+		Pattern:  regexp.MustCompile(`(?i)AmsiUtils|amsiInitFailed|AmsiScanBuffer`),
+	},
+	{
+		Name:     "execution-policy-bypass",
+		Severity: ScriptSeverityWarning,
+		Message:  "The execution policy is set to Bypass or Unrestricted",
+		Pattern:  regexp.MustCompile(`(?i)(Set-ExecutionPolicy[^\n]*\b(Bypass|Unrestricted)\b)|(^|\s)-(ExecutionPolicy|ep|exec)\s+(Bypass|Unrestricted)\b`),
+	},
+}
+
+// CheckPowershellScript verifies that the quotes, braces, here-strings and block comments of the script are balanced
+// and evaluates the rules against its code. Use DefaultScriptRules or a custom rule set, a nil rule set only checks
+// the syntax. Findings are sorted by position.
+func CheckPowershellScript(script string, rules []ScriptRule) []ScriptFinding {
+	code, findings := tokenizePowershell(script)
+
+	lines := newLineIndex(script)
+	for _, rule := range rules {
+		if rule.Pattern == nil {
+			continue
+		}
+		for _, m := range rule.Pattern.FindAllStringIndex(code, -1) {
+			// Patterns may match the whitespace before the command
+			start := m[0] + len(code[m[0]:m[1]]) - len(strings.TrimLeft(code[m[0]:m[1]], " \t\r\n"))
+			line, column := lines.position(start)
+			findings = append(findings, ScriptFinding{Rule: rule.Name, Severity: rule.Severity, Message: rule.Message, Line: line, Column: column})
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Line != findings[j].Line {
+			return findings[i].Line < findings[j].Line
+		}
+		return findings[i].Column < findings[j].Column
+	})
+
+	return findings
+}
+
+var singleQuotedHereStringEnd = regexp.MustCompile(`(?m)^[ \t]*'@`)
+
+type powershellFrame struct {
+	kind   rune
+	offset int
+}
+
+// tokenizePowershell scans the script and returns it with the comments blanked out, keeping the offsets
+// of the remaining code, and the syntax findings. Frames track the open braces, parenthesis, brackets,
+// double quoted strings and here-strings ('H') and the subexpressions inside them ('S').
+func tokenizePowershell(script string) (string, []ScriptFinding) {
+	lines := newLineIndex(script)
+	findings := []ScriptFinding{}
+	syntaxError := func(offset int, message string) {
+		line, column := lines.position(offset)
+		findings = append(findings, ScriptFinding{Rule: ScriptSyntaxRule, Severity: ScriptSeverityError, Message: message, Line: line, Column: column})
+	}
+
+	code := []byte(script)
+	blank := func(from, to int) {
+		for i := from; i < to && i < len(code); i++ {
+			if code[i] != '\n' && code[i] != '\r' {
+				code[i] = ' '
+			}
+		}
+	}
+
+	atLineStart := func(i int) bool {
+		j := i - 1
+		for j >= 0 && (script[j] == ' ' || script[j] == '\t') {
+			j--
+		}
+		return j < 0 || script[j] == '\n'
+	}
+
+	// hereStringOpener reports whether the here-string opener at i is followed by a line break
+	hereStringOpener := func(i int) bool {
+		rest := strings.TrimLeft(script[i+2:], " \t")
+		return strings.HasPrefix(rest, "\n") || strings.HasPrefix(rest, "\r\n")
+	}
+
+	stack := []powershellFrame{}
+	top := func() rune {
+		if len(stack) == 0 {
+			return 0
+		}
+		return stack[len(stack)-1].kind
+	}
+
+	closing := map[byte]rune{'}': '{', ')': '(', ']': '['}
+
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+
+		// Inside double quoted strings and here-strings only escapes, subexpressions and the terminator matter
+		if kind := top(); kind == '"' || kind == 'H' {
+			switch {
+			case c == '`':
+				i++
+			case c == '$' && i+1 < len(script) && script[i+1] == '(':
+				stack = append(stack, powershellFrame{kind: 'S', offset: i})
+				i++
+			case kind == '"' && c == '"':
+				if i+1 < len(script) && script[i+1] == '"' {
+					i++
+				} else {
+					stack = stack[:len(stack)-1]
+				}
+			case kind == 'H' && c == '"' && i+1 < len(script) && script[i+1] == '@' && atLineStart(i):
+				stack = stack[:len(stack)-1]
+				i++
+			}
+			continue
+		}
+
+		switch {
+		case c == '`':
+			i++
+
+		case c == '<' && i+1 < len(script) && script[i+1] == '#':
+			end := strings.Index(script[i+2:], "#>")
+			if end < 0 {
+				syntaxError(i, "block comment is not terminated")
+				blank(i, len(script))
+				i = len(script)
+				continue
+			}
+			blank(i, i+2+end+2)
+			i += 2 + end + 1
+
+		case c == '#':
+			end := strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				end = len(script) - i
+			}
+			blank(i, i+end)
+			i += end - 1
+
+		case c == '@' && i+1 < len(script) && script[i+1] == '\'' && hereStringOpener(i):
+			end := singleQuotedHereStringEnd.FindStringIndex(script[i+2:])
+			if end == nil {
+				syntaxError(i, "here-string is not terminated, '@ must be at the beginning of a line")
+				i = len(script)
+				continue
+			}
+			i += 2 + end[1] - 1
+
+		case c == '@' && i+1 < len(script) && script[i+1] == '"' && hereStringOpener(i):
+			stack = append(stack, powershellFrame{kind: 'H', offset: i})
+			i++
+
+		case c == '\'':
+			j := i + 1
+			for ; j < len(script); j++ {
+				if script[j] == '\'' {
+					if j+1 < len(script) && script[j+1] == '\'' {
+						j++
+						continue
+					}
+					break
+				}
+			}
+			if j >= len(script) {
+				syntaxError(i, "single quoted string is not terminated")
+			}
+			i = j
+
+		case c == '"':
+			stack = append(stack, powershellFrame{kind: '"', offset: i})
+
+		case c == '{' || c == '(' || c == '[':
+			stack = append(stack, powershellFrame{kind: rune(c), offset: i})
+
+		case c == '}' || c == ')' || c == ']':
+			kind := top()
+			if kind == closing[c] || (c == ')' && kind == 'S') {
+				stack = stack[:len(stack)-1]
+			} else {
+				syntaxError(i, "unexpected "+string(c))
+			}
+		}
+	}
+
+	for _, frame := range stack {
+		switch frame.kind {
+		case '"':
+			syntaxError(frame.offset, "double quoted string is not terminated")
+		case 'H':
+			syntaxError(frame.offset, "here-string is not terminated, \"@ must be at the beginning of a line")
+		case 'S':
+			syntaxError(frame.offset, "subexpression is not closed")
+		default:
+			syntaxError(frame.offset, string(frame.kind)+" is not closed")
+		}
+	}
+
+	return string(code), findings
+}
+
+type lineIndex struct {
+	source string
+	starts []int
+}
+
+// newLineIndex stores the offset where each line starts
+func newLineIndex(s string) lineIndex {
+	index := lineIndex{source: s, starts: []int{0}}
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\n' {
+			index.starts = append(index.starts, i+1)
+		}
+	}
+	return index
+}
+
+// position converts an offset to a line and a column counted in characters
+func (index lineIndex) position(offset int) (int, int) {
+	line := sort.Search(len(index.starts), func(i int) bool { return index.starts[i] > offset }) - 1
+	column := utf8.RuneCountInString(index.source[index.starts[line]:offset]) + 1
+	return line + 1, column
+}
@@ -0,0 +1,31 @@
+package wingetcfg
+
+import "testing"
+
+func TestEncodedCommandRule(t *testing.T) {
+	tests := []struct {
+		script  string
+		flagged bool
+	}{
+		{"powershell -EncodedC SQBFAFgAIABoAGUAbABsAG8A", true},
+		{"powershell.exe -NoProfile -enc SQBFAFgAIABoAGUAbABsAG8A", true},
+		{"pwsh -ec 'SQBFAFgAIABoAGUAbABsAG8A'", true},
+		{"Start-Process powershell -ArgumentList '-e SQBFAFgAIABoAGUAbABsAG8A'", true},
+		{"Start-Process notepad -e abcdefghijklmnopqrstu", false},
+		{"powershell -ExecutionPolicy RemoteSigned -File abcdefghijklmnopqrstu.ps1", false},
+		{"powershell -File setup.ps1; Start-Process notepad -e abcdefghijklmnopqrstu", false},
+		{"# powershell -enc SQBFAFgAIABoAGUAbABsAG8A", false},
+	}
+
+	for _, tt := range tests {
+		flagged := false
+		for _, f := range CheckPowershellScript(tt.script, DefaultScriptRules) {
+			if f.Rule == "encoded-command" {
+				flagged = true
+			}
+		}
+		if flagged != tt.flagged {
+			t.Errorf("%s: got flagged %t, want %t", tt.script, flagged, tt.flagged)
+		}
+	}
+}
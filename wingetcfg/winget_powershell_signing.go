@@ -0,0 +1,220 @@
+package wingetcfg
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	scriptSignatureBegin = "# SIG # Begin signature block"
+	scriptSignatureEnd   = "# SIG # End signature block"
+)
+
+var scriptSignatureBlock = regexp.MustCompile(`(?s)\r?\n?` + regexp.QuoteMeta(scriptSignatureBegin) + `\r?\n(.*?)` + regexp.QuoteMeta(scriptSignatureEnd) + `\s*$`)
+
+// ScriptSigner signs the SHA-256 digest of a script
+type ScriptSigner interface {
+	// Name identifies the key used to sign, it's stored in the ScriptSigner setting
+	Name() string
+	Sign(digest []byte) ([]byte, error)
+}
+
+// ScriptVerifier verifies the signature of the SHA-256 digest of a script
+type ScriptVerifier interface {
+	Verify(signer string, digest []byte, signature []byte) error
+}
+
+// LocalScriptSigner signs and verifies scripts with a local Ed25519 key, it's meant for tests
+// and small deployments where the agent is provisioned with the public key
+type LocalScriptSigner struct {
+	name       string
+	privateKey ed25519.PrivateKey
+	publicKey  ed25519.PublicKey
+}
+
+// NewLocalScriptSigner creates a signer from an Ed25519 private key
+func NewLocalScriptSigner(name string, privateKey ed25519.PrivateKey) (*LocalScriptSigner, error) {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("private key is not a valid Ed25519 key")
+	}
+	return &LocalScriptSigner{name: name, privateKey: privateKey, publicKey: privateKey.Public().(ed25519.PublicKey)}, nil
+}
+
+// GenerateLocalScriptSigner creates a signer with a new random key
+func GenerateLocalScriptSigner(name string) (*LocalScriptSigner, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return NewLocalScriptSigner(name, privateKey)
+}
+
+// NewLocalScriptVerifier creates a verifier that only has the public key
+func NewLocalScriptVerifier(name string, publicKey ed25519.PublicKey) (*LocalScriptSigner, error) {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return nil, errors.New("public key is not a valid Ed25519 key")
+	}
+	return &LocalScriptSigner{name: name, publicKey: publicKey}, nil
+}
+
+func (s *LocalScriptSigner) Name() string {
+	return s.name
+}
+
+func (s *LocalScriptSigner) PublicKey() ed25519.PublicKey {
+	return s.publicKey
+}
+
+func (s *LocalScriptSigner) Sign(digest []byte) ([]byte, error) {
+	if s.privateKey == nil {
+		return nil, errors.New("the signer has no private key")
+	}
+	return ed25519.Sign(s.privateKey, digest), nil
+}
+
+func (s *LocalScriptSigner) Verify(signer string, digest []byte, signature []byte) error {
+	if signer != s.name {
+		return fmt.Errorf("script was signed by %s, expected %s", signer, s.name)
+	}
+	if !ed25519.Verify(s.publicKey, digest, signature) {
+		return errors.New("script signature is not valid")
+	}
+	return nil
+}
+
+// ScriptHash returns the SHA-256 of the script body as an hexadecimal string,
+// the signature block is not part of the body
+func ScriptHash(script string) string {
+	digest := sha256.Sum256([]byte(scriptBody(script)))
+	return strings.ToUpper(hex.EncodeToString(digest[:]))
+}
+
+// AddScriptHash computes the SHA-256 of the script of a resource created with ExecutePowershellScript
+// and stores it in the ScriptHash and ScriptHashAlgorithm settings
+func AddScriptHash(r *WinGetResource) error {
+	script, err := powershellScript(r)
+	if err != nil {
+		return err
+	}
+
+	r.Settings["ScriptHash"] = ScriptHash(script)
+	r.Settings["ScriptHashAlgorithm"] = FileHashSHA256
+	return nil
+}
+
+// SignScript adds the hash of the script of a resource created with ExecutePowershellScript and appends
+// an Authenticode-style signature block with the signature of the hash. The signer is stored in the ScriptSigner setting.
+func SignScript(r *WinGetResource, signer ScriptSigner) error {
+	script, err := powershellScript(r)
+	if err != nil {
+		return err
+	}
+
+	// The line break before the block is not part of the body
+	body := strings.TrimRight(scriptBody(script), "\r\n")
+	digest := sha256.Sum256([]byte(body))
+	signature, err := signer.Sign(digest[:])
+	if err != nil {
+		return fmt.Errorf("could not sign script: %v", err)
+	}
+
+	block := []string{scriptSignatureBegin}
+	encoded := base64.StdEncoding.EncodeToString(signature)
+	for len(encoded) > 64 {
+		block = append(block, "# "+encoded[:64])
+		encoded = encoded[64:]
+	}
+	block = append(block, "# "+encoded, scriptSignatureEnd)
+
+	r.Settings["Script"] = body + "\n" + strings.Join(block, "\n") + "\n"
+	r.Settings["ScriptSigner"] = signer.Name()
+	return AddScriptHash(r)
+}
+
+// VerifyScript checks that the script of a resource created with ExecutePowershellScript matches its
+// ScriptHash. When a verifier is given the script must have a signature block and a ScriptSigner, and the
+// signature is verified with it. A nil verifier only checks the hash.
+func VerifyScript(r *WinGetResource, verifier ScriptVerifier) error {
+	script, err := powershellScript(r)
+	if err != nil {
+		return err
+	}
+
+	expected := settingString(r.Settings, "ScriptHash")
+	if expected == "" {
+		return fmt.Errorf("script %s has no hash", settingString(r.Settings, "ID"))
+	}
+	if algorithm := settingString(r.Settings, "ScriptHashAlgorithm"); algorithm != "" && algorithm != FileHashSHA256 {
+		return fmt.Errorf("script %s: hash algorithm %s is not supported", settingString(r.Settings, "ID"), algorithm)
+	}
+	if !strings.EqualFold(expected, ScriptHash(script)) {
+		return fmt.Errorf("script %s has been tampered, its hash doesn't match", settingString(r.Settings, "ID"))
+	}
+
+	if verifier == nil {
+		return nil
+	}
+
+	m := scriptSignatureBlock.FindStringSubmatch(script)
+	if m == nil {
+		return fmt.Errorf("script %s is not signed", settingString(r.Settings, "ID"))
+	}
+	signer := settingString(r.Settings, "ScriptSigner")
+	if signer == "" {
+		return fmt.Errorf("script %s has no signer", settingString(r.Settings, "ID"))
+	}
+
+	encoded := ""
+	for _, line := range strings.Split(m[1], "\n") {
+		encoded += strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "#"))
+	}
+	signature, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("script %s: signature block is not valid", settingString(r.Settings, "ID"))
+	}
+
+	digest := sha256.Sum256([]byte(scriptBody(script)))
+	if err := verifier.Verify(signer, digest[:], signature); err != nil {
+		return fmt.Errorf("script %s: %v", settingString(r.Settings, "ID"), err)
+	}
+	return nil
+}
+
+// verifyScriptHashes verifies the custom PowerShell resources that carry a hash. When a verifier
+// is given every PowerShell resource must have a hash and a valid signature.
+func (cfg *WinGetCfg) verifyScriptHashes(verifier ScriptVerifier) error {
+	for _, r := range append(append([]*WinGetResource{}, cfg.Properties.Assertions...), cfg.Properties.Resources...) {
+		if r == nil || r.Resource != scnorionplusPowershell {
+			continue
+		}
+		if verifier == nil && settingString(r.Settings, "ScriptHash") == "" {
+			continue
+		}
+		if err := VerifyScript(r, verifier); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func powershellScript(r *WinGetResource) (string, error) {
+	if r == nil || r.Resource != scnorionplusPowershell {
+		return "", errors.New("resource is not a PowerShell script resource")
+	}
+	return settingString(r.Settings, "Script"), nil
+}
+
+// scriptBody removes the signature block from the script
+func scriptBody(script string) string {
+	if loc := scriptSignatureBlock.FindStringIndex(script); loc != nil {
+		return script[:loc[0]]
+	}
+	return script
+}
@@ -0,0 +1,101 @@
+package wingetcfg
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestVerifyScriptRequiresSignature(t *testing.T) {
+	signer, err := GenerateLocalScriptSigner("deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := NewLocalScriptVerifier("deploy", signer.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed, err := ExecutePowershellScript("signed", "Signed", "Write-Output 'signed'", ScriptRunOnce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SignScript(signed, signer); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyScript(signed, verifier); err != nil {
+		t.Errorf("signed script: %v", err)
+	}
+
+	hashed, err := ExecutePowershellScript("hashed", "Hashed", "Write-Output 'hashed'", ScriptRunOnce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := AddScriptHash(hashed); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyScript(hashed, nil); err != nil {
+		t.Errorf("hashed script without verifier: %v", err)
+	}
+	if err := VerifyScript(hashed, verifier); err == nil {
+		t.Error("a script without signature block must be rejected when a verifier is given")
+	}
+
+	unsigner, err := ExecutePowershellScript("nosigner", "No signer", "Write-Output 'signed'", ScriptRunOnce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SignScript(unsigner, signer); err != nil {
+		t.Fatal(err)
+	}
+	delete(unsigner.Settings, "ScriptSigner")
+	if err := VerifyScript(unsigner, verifier); err == nil {
+		t.Error("a script without ScriptSigner must be rejected when a verifier is given")
+	}
+}
+
+func TestParseSignedConfig(t *testing.T) {
+	signer, err := GenerateLocalScriptSigner("deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed, err := ExecutePowershellScript("signed", "Signed", "Write-Output 'signed'", ScriptRunOnce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SignScript(signed, signer); err != nil {
+		t.Fatal(err)
+	}
+	unsigned, err := ExecutePowershellScript("unsigned", "Unsigned", "Write-Output 'unsigned'", ScriptRunOnce)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	marshal := func(resources ...*WinGetResource) []byte {
+		cfg := NewWingetCfg()
+		for _, r := range resources {
+			cfg.AddResource(r)
+		}
+		data, err := yaml.Marshal(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	if _, err := ParseSignedConfig(marshal(signed), signer); err != nil {
+		t.Errorf("signed configuration: %v", err)
+	}
+	if _, err := ParseSignedConfig(marshal(signed, unsigned), signer); err == nil {
+		t.Error("a configuration with an unsigned script must be rejected")
+	}
+	if _, err := ParseConfig(marshal(signed, unsigned)); err != nil {
+		t.Errorf("unsigned scripts are allowed when signatures are not required: %v", err)
+	}
+
+	signed.Settings["Script"] = "Write-Output 'tampered'\n" + settingString(signed.Settings, "Script")
+	if _, err := ParseSignedConfig(marshal(signed), signer); err == nil {
+		t.Error("a tampered script must be rejected")
+	}
+}
@@ -2,6 +2,7 @@ package wingetcfg
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 )
 
@@ -50,6 +51,17 @@ func AddRegistryValue(ID string, description string, key string, valueName strin
 	return NewWinGetRegistryResource(ID, description, key, valueName, valueType, valueData, EnsurePresent, hex, force)
 }
 
+// AddRegistryMultiStringValue creates a new WinGetResource that contains the settings to add a new MultiString registry value.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// Key specifies the path to the registry key as a string. This path must include the registry hive or drive, such as HKEY_LOCAL_MACHINE or HKLM:.
+// valueName specifies the name of the registry value as a string.
+// valueData specifies the strings stored in the registry value, they're emitted as a YAML list.
+// force specifies whether to overwrite the registry key value if it already has a value.
+func AddRegistryMultiStringValue(ID string, description string, key string, valueName string, valueData []string, force bool) (*WinGetResource, error) {
+	return newWinGetRegistryResource(ID, description, key, valueName, RegistryValueTypeMultistring, valueData, EnsurePresent, false, force)
+}
+
 // RemoveRegistryKey removes a registry key.
 // ID is an optional identifier.
 // Description is an optional text that describes the task to be performed.
@@ -72,12 +84,14 @@ func RemoveRegistryValue(ID string, description string, key string, valueName st
 // ID is an optional identifier.
 // Description is an optional text that describes the task to be performed.
 // Key specifies the path to the registry key as a string. This path must include the registry hive or drive, such as HKEY_LOCAL_MACHINE or HKLM:.
+// The key is normalized to the long form, e.g. HKEY_LOCAL_MACHINE\Software, so resources that manage the same key can be
+// compared, see FormatRegistryKeys to use another form.
 // valueName specifies the name of the registry value as a string. To add or remove a registry key, specify this property as an empty string without
 // specifying the ValueType or ValueData property. To update or remove the default value of a registry key, specify this property as an empty string
 // with the ValueType or ValueData property.
 // valueType specifies the type for the specified registry key value's data which is one of String, Binary, DWord, QWord, MultiString, ExpandString
-// valueData specifies the registry key value as an array of string. If ValueType isn't MultiString and this property's value is multiple strings,
-// the function returns an invalid argument exception.
+// valueData specifies the registry key value as a string. Several strings can be passed separated by a newline, if ValueType isn't MultiString
+// and this property's value is multiple strings, the function returns an error. MultiString data is emitted as a YAML list.
 // ensure specifies whether the registry key or value should exist. To add or update a registry key or value, set this property to Present. To remove
 // a registry key or value, set this property to Absent.
 // hex specifies whether the specified registry key data is provided in a hexadecimal format. Specify this property only when valueType is DWord or QWord.
@@ -85,6 +99,14 @@ func RemoveRegistryValue(ID string, description string, key string, valueName st
 // force specifies whether to overwrite the registry key value if it already has a value or to delete a registry key that has subkeys.
 // Reference: https://github.com/dsccommunity/xPSDesiredStateConfiguration/blob/main/source/DSCResources/DSC_xRegistryResource/DSC_xRegistryResource.psm1
 func NewWinGetRegistryResource(ID string, description string, key string, valueName string, valueType string, valueData string, ensure string, hex bool, force bool) (*WinGetResource, error) {
+	var data []string
+	if valueData != "" {
+		data = strings.Split(valueData, "\n")
+	}
+	return newWinGetRegistryResource(ID, description, key, valueName, valueType, data, ensure, hex, force)
+}
+
+func newWinGetRegistryResource(ID string, description string, key string, valueName string, valueType string, valueData []string, ensure string, hex bool, force bool) (*WinGetResource, error) {
 	r := WinGetResource{}
 	r.Resource = WinGetRegistryResource
 
@@ -103,24 +125,36 @@ func NewWinGetRegistryResource(ID string, description string, key string, valueN
 	if key == "" {
 		return nil, errors.New("key cannot be empty")
 	}
-	r.Settings["Key"] = key
+	name := registryResourceName(ID, key, valueName)
+	path, err := ParseRegistryPath(key)
+	if err != nil {
+		return nil, fmt.Errorf("registry resource %s: Key %s must start with one of the HKLM, HKCU, HKU, HKCR or HKCC hives", name, key)
+	}
+	r.Settings["Key"] = path.Format(RegistryPathLong)
 
 	r.Settings["ValueName"] = valueName
 
 	if valueType != "" {
 		if !IsValidRegistryValueType(valueType) {
-			return nil, errors.New("value type is not valid")
+			return nil, fmt.Errorf("registry resource %s: ValueType %s is not valid", name, valueType)
 		}
 		r.Settings["ValueType"] = valueType
 	}
 
-	if valueData != "" {
-		data := strings.Split(valueData, "\n")
-		if len(data) > 1 {
-			return nil, errors.New("more than one string has been passed but type is not Multitring")
+	if len(valueData) > 0 {
+		if valueType == RegistryValueTypeMultistring {
+			r.Settings["ValueData"] = valueData
+		} else {
+			if len(valueData) > 1 {
+				return nil, fmt.Errorf("registry resource %s: ValueData has more than one string but ValueType is not MultiString", name)
+			}
+
+			data, err := normalizeRegistryValueData(name, valueType, valueData[0], hex)
+			if err != nil {
+				return nil, err
+			}
+			r.Settings["ValueData"] = data
 		}
-
-		r.Settings["ValueData"] = valueData
 	}
 
 	if force {
@@ -143,3 +177,33 @@ func IsValidRegistryValueType(registryValueType string) bool {
 	}
 	return false
 }
+
+// clearRegistryKeyResource creates a script resource that removes the values of a key as the **delvals.
+// directive of Group Policy does. The default value and the values that the Present xRegistry resources
+// write into the key are kept, so they're not removed and written again on every run. The key is never
+// deleted, xRegistry would fail if it has subkeys and other resources may write into it.
+func clearRegistryKeyResource(description string, key string, resources []*WinGetResource) (*WinGetResource, error) {
+	path, err := ParseRegistryPath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := []string{"'(default)'"}
+	for _, r := range resources {
+		if r == nil || r.Resource != WinGetRegistryResource || settingString(r.Settings, "Ensure") != EnsurePresent {
+			continue
+		}
+		valueName := settingString(r.Settings, "ValueName")
+		if other, err := ParseRegistryPath(settingString(r.Settings, "Key")); err == nil && other.Equal(path) && valueName != "" {
+			keep = append(keep, powershellQuote(valueName))
+		}
+	}
+
+	literalPath := powershellQuote(`Registry::` + path.String())
+	preamble := fmt.Sprintf("$keep = @(%s)\n$key = Get-Item -LiteralPath %s -ErrorAction SilentlyContinue\n", strings.Join(keep, ", "), literalPath)
+	getScript := preamble + "@{ Result = @($key.Property | Where-Object { $keep -notcontains $_ }) -join ';' }"
+	testScript := preamble + "return ($null -eq $key) -or -not @($key.Property | Where-Object { $keep -notcontains $_ })"
+	setScript := preamble + fmt.Sprintf("if ($null -ne $key) {\n    $key.Property | Where-Object { $keep -notcontains $_ } | ForEach-Object { Remove-ItemProperty -LiteralPath %s -Name $_ }\n}", literalPath)
+
+	return NewScriptResource("", description, PSDscScriptResource, getScript, testScript, setScript)
+}
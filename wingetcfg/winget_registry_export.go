@@ -0,0 +1,242 @@
+package wingetcfg
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+const RegFileHeader = "Windows Registry Editor Version 5.00"
+
+// WriteRegFile writes the registry resources found in the configuration to a .reg file
+// that can be reviewed or imported with the Registry Editor.
+func (cfg *WinGetCfg) WriteRegFile(filePath string) error {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := cfg.ExportRegistry(f); err != nil {
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ExportRegistry walks all the xRegistry resources of the configuration (assertions are ignored)
+// and writes them to w using the Registry Editor 5.00 format. The output is UTF-16LE encoded with
+// a byte order mark and CRLF line endings as expected by regedit.
+// Resources with Ensure set to Absent are exported as delete entries, a key is deleted
+// when no value name nor value type are set, otherwise the value is deleted.
+func (cfg *WinGetCfg) ExportRegistry(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString(RegFileHeader + "\r\n")
+
+	currentKey := ""
+	for _, r := range cfg.Properties.Resources {
+		if r == nil || r.Resource != WinGetRegistryResource {
+			continue
+		}
+
+		path, err := ParseRegistryPath(settingString(r.Settings, "Key"))
+		if err != nil {
+			return err
+		}
+		key := path.String()
+
+		valueName := settingString(r.Settings, "ValueName")
+		valueType := settingString(r.Settings, "ValueType")
+		absent := settingString(r.Settings, "Ensure") == EnsureAbsent
+
+		// Delete a whole key
+		if absent && valueName == "" && valueType == "" {
+			sb.WriteString("\r\n[-" + key + "]\r\n")
+			currentKey = ""
+			continue
+		}
+
+		if !strings.EqualFold(key, currentKey) {
+			sb.WriteString("\r\n[" + key + "]\r\n")
+			currentKey = key
+		}
+
+		// Only the key has to be created
+		if !absent && valueName == "" && valueType == "" {
+			continue
+		}
+
+		name := "@"
+		if valueName != "" {
+			name = `"` + regFileEscape(valueName) + `"`
+		}
+
+		if absent {
+			sb.WriteString(name + "=-\r\n")
+			continue
+		}
+
+		data, err := regFileValueData(r)
+		if err != nil {
+			return fmt.Errorf("could not export registry value %s of key %s: %v", name, key, err)
+		}
+		sb.WriteString(name + "=" + data + "\r\n")
+	}
+
+	bw := bufio.NewWriter(w)
+	// UTF-16LE BOM
+	if _, err := bw.Write([]byte{0xFF, 0xFE}); err != nil {
+		return err
+	}
+	if _, err := bw.Write(utf16LEBytes(sb.String())); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// regFileValueData returns the right side of a .reg value assignment for a registry resource
+func regFileValueData(r *WinGetResource) (string, error) {
+	valueType := settingString(r.Settings, "ValueType")
+	valueData := settingString(r.Settings, "ValueData")
+	hex, _ := r.Settings["Hex"].(bool)
+
+	switch valueType {
+	case "", RegistryValueTypeString:
+		return `"` + regFileEscape(valueData) + `"`, nil
+	case RegistryValueTypeExpandString:
+		return "hex(2):" + regFileHexBytes(utf16LEBytes(valueData+"\x00")), nil
+	case RegistryValueTypeMultistring:
+		data := ""
+		if values := settingStrings(r.Settings, "ValueData"); len(values) > 0 {
+			data = strings.Join(values, "\x00") + "\x00"
+		}
+		return "hex(7):" + regFileHexBytes(utf16LEBytes(data+"\x00")), nil
+	case RegistryValueTypeBinary:
+		b, err := parseRegistryBinary(valueData)
+		if err != nil {
+			return "", err
+		}
+		return "hex:" + regFileHexBytes(b), nil
+	case RegistryValueTypeDWord:
+		v, err := parseRegistryNumber(valueData, hex, 32)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("dword:%08x", uint32(v)), nil
+	case RegistryValueTypeQWord:
+		v, err := parseRegistryNumber(valueData, hex, 64)
+		if err != nil {
+			return "", err
+		}
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, v)
+		return "hex(b):" + regFileHexBytes(b), nil
+	}
+
+	return "", fmt.Errorf("value type %s is not valid", valueType)
+}
+
+// parseRegistryNumber parses DWord and QWord data as xRegistry does, as a hexadecimal
+// number (with or without the 0x prefix) if hex is true or as a decimal number otherwise.
+// Negative decimal numbers are accepted and stored using two's complement.
+func parseRegistryNumber(data string, hex bool, bitSize int) (uint64, error) {
+	data = strings.TrimSpace(data)
+	if data == "" {
+		return 0, nil
+	}
+
+	if hex {
+		data = strings.TrimPrefix(strings.TrimPrefix(data, "0x"), "0X")
+		return strconv.ParseUint(data, 16, bitSize)
+	}
+
+	if strings.HasPrefix(data, "-") {
+		v, err := strconv.ParseInt(data, 10, bitSize)
+		if err != nil {
+			return 0, err
+		}
+		if bitSize == 32 {
+			return uint64(uint32(int32(v))), nil
+		}
+		return uint64(v), nil
+	}
+
+	return strconv.ParseUint(data, 10, bitSize)
+}
+
+// parseRegistryBinary parses Binary data given as a hexadecimal string, with or without the 0x prefix
+func parseRegistryBinary(data string) ([]byte, error) {
+	data = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(data), "0x"), "0X")
+	if len(data)%2 != 0 {
+		data = "0" + data
+	}
+
+	b := make([]byte, len(data)/2)
+	for i := range b {
+		v, err := strconv.ParseUint(data[2*i:2*i+2], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("binary data %s is not a valid hexadecimal string", data)
+		}
+		b[i] = byte(v)
+	}
+	return b, nil
+}
+
+func regFileEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+func regFileHexBytes(b []byte) string {
+	hex := make([]string, len(b))
+	for i, v := range b {
+		hex[i] = fmt.Sprintf("%02x", v)
+	}
+	return strings.Join(hex, ",")
+}
+
+func utf16LEBytes(s string) []byte {
+	u := utf16.Encode([]rune(s))
+	b := make([]byte, 2*len(u))
+	for i, v := range u {
+		binary.LittleEndian.PutUint16(b[2*i:], v)
+	}
+	return b
+}
+
+func settingString(settings map[string]any, name string) string {
+	if v, ok := settings[name].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// settingStrings returns a setting that may be stored as a string, a list of strings
+// or a list of values as decoded from YAML
+func settingStrings(settings map[string]any, name string) []string {
+	switch v := settings[name].(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return strings.Split(v, "\n")
+	case []string:
+		return v
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return values
+	}
+	return nil
+}
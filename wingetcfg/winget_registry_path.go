@@ -0,0 +1,150 @@
+package wingetcfg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type RegistryHive string
+
+const (
+	RegistryHiveLocalMachine  RegistryHive = "HKEY_LOCAL_MACHINE"
+	RegistryHiveCurrentUser   RegistryHive = "HKEY_CURRENT_USER"
+	RegistryHiveUsers         RegistryHive = "HKEY_USERS"
+	RegistryHiveClassesRoot   RegistryHive = "HKEY_CLASSES_ROOT"
+	RegistryHiveCurrentConfig RegistryHive = "HKEY_CURRENT_CONFIG"
+)
+
+// RegistryPathForm specifies how the hive of a registry path is written
+type RegistryPathForm int
+
+const (
+	// RegistryPathLong writes the hive with its long name, e.g HKEY_LOCAL_MACHINE\Software
+	RegistryPathLong RegistryPathForm = iota
+	// RegistryPathShort writes the hive with its abbreviation, e.g HKLM\Software. It's meant for display and
+	// comparison, xRegistry doesn't accept it.
+	RegistryPathShort
+	// RegistryPathDrive writes the hive as the drive used by xRegistry, e.g HKLM:\Software.
+	// HKEY_USERS is written as HKUS:\.
+	RegistryPathDrive
+)
+
+var registryHiveAbbreviations = map[RegistryHive]string{
+	RegistryHiveLocalMachine:  "HKLM",
+	RegistryHiveCurrentUser:   "HKCU",
+	RegistryHiveUsers:         "HKU",
+	RegistryHiveClassesRoot:   "HKCR",
+	RegistryHiveCurrentConfig: "HKCC",
+}
+
+// registryHiveDrives are the drive names xRegistry uses for the hives
+var registryHiveDrives = map[RegistryHive]string{
+	RegistryHiveLocalMachine:  "HKLM:",
+	RegistryHiveCurrentUser:   "HKCU:",
+	RegistryHiveUsers:         "HKUS:",
+	RegistryHiveClassesRoot:   "HKCR:",
+	RegistryHiveCurrentConfig: "HKCC:",
+}
+
+// RegistryPath is a registry key path split in its hive and its subkey
+type RegistryPath struct {
+	Hive   RegistryHive
+	SubKey string
+}
+
+// ParseRegistryPath parses a registry key path whose hive is written in its long (HKEY_LOCAL_MACHINE),
+// short (HKLM) or drive (HKLM:, HKUS:) form. Hives are case insensitive, repeated and trailing
+// backslashes are removed from the subkey.
+func ParseRegistryPath(key string) (RegistryPath, error) {
+	hive, subKey, _ := strings.Cut(strings.TrimSpace(key), `\`)
+	hive = strings.ToUpper(hive)
+
+	p := RegistryPath{}
+	for long, short := range registryHiveAbbreviations {
+		if hive == string(long) || hive == short || hive == short+":" || hive == registryHiveDrives[long] {
+			p.Hive = long
+			break
+		}
+	}
+	if p.Hive == "" {
+		return RegistryPath{}, fmt.Errorf("registry key %s must start with one of the HKLM, HKCU, HKU, HKCR or HKCC hives", key)
+	}
+
+	parts := []string{}
+	for _, part := range strings.Split(subKey, `\`) {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	p.SubKey = strings.Join(parts, `\`)
+
+	return p, nil
+}
+
+// Format returns the registry path using the specified form for the hive
+func (p RegistryPath) Format(form RegistryPathForm) string {
+	hive := string(p.Hive)
+	switch form {
+	case RegistryPathShort:
+		hive = registryHiveAbbreviations[p.Hive]
+	case RegistryPathDrive:
+		hive = registryHiveDrives[p.Hive]
+	}
+
+	if p.SubKey == "" {
+		if form == RegistryPathDrive {
+			return hive + `\`
+		}
+		return hive
+	}
+	return hive + `\` + p.SubKey
+}
+
+// String returns the registry path using the long form for the hive
+func (p RegistryPath) String() string {
+	return p.Format(RegistryPathLong)
+}
+
+// Equal reports whether both paths point to the same key, registry keys are case insensitive
+func (p RegistryPath) Equal(other RegistryPath) bool {
+	return p.Hive == other.Hive && strings.EqualFold(p.SubKey, other.SubKey)
+}
+
+// SameRegistryResource reports whether two xRegistry resources manage the same registry key or value,
+// no matter the form used to write their keys
+func SameRegistryResource(a, b *WinGetResource) bool {
+	if a == nil || b == nil || a.Resource != WinGetRegistryResource || b.Resource != WinGetRegistryResource {
+		return false
+	}
+
+	pathA, err := ParseRegistryPath(settingString(a.Settings, "Key"))
+	if err != nil {
+		return false
+	}
+	pathB, err := ParseRegistryPath(settingString(b.Settings, "Key"))
+	if err != nil {
+		return false
+	}
+
+	return pathA.Equal(pathB) && strings.EqualFold(settingString(a.Settings, "ValueName"), settingString(b.Settings, "ValueName"))
+}
+
+// FormatRegistryKeys rewrites the Key setting of the xRegistry resources of the configuration using
+// the specified form. The registry constructors always write the long form, use this to write the keys
+// as drives instead. Only the long and drive forms are accepted as they're the ones xRegistry supports.
+func (cfg *WinGetCfg) FormatRegistryKeys(form RegistryPathForm) error {
+	if form != RegistryPathLong && form != RegistryPathDrive {
+		return errors.New("registry keys can only be written in the long or drive forms supported by xRegistry")
+	}
+
+	for _, r := range append(append([]*WinGetResource{}, cfg.Properties.Assertions...), cfg.Properties.Resources...) {
+		if r == nil || r.Resource != WinGetRegistryResource {
+			continue
+		}
+		if path, err := ParseRegistryPath(settingString(r.Settings, "Key")); err == nil {
+			r.Settings["Key"] = path.Format(form)
+		}
+	}
+	return nil
+}
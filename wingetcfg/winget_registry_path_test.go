@@ -0,0 +1,50 @@
+package wingetcfg
+
+import "testing"
+
+func TestParseRegistryPath(t *testing.T) {
+	for _, key := range []string{`HKEY_USERS\S-1-5-18\Software`, `HKU\S-1-5-18\Software`, `HKU:\S-1-5-18\Software`, `hkus:\S-1-5-18\Software\`} {
+		p, err := ParseRegistryPath(key)
+		if err != nil {
+			t.Errorf("%s: %v", key, err)
+			continue
+		}
+		if p.Hive != RegistryHiveUsers || p.SubKey != `S-1-5-18\Software` {
+			t.Errorf("%s: got %+v", key, p)
+		}
+	}
+
+	p := RegistryPath{Hive: RegistryHiveUsers, SubKey: `S-1-5-18\Software`}
+	for form, want := range map[RegistryPathForm]string{
+		RegistryPathLong:  `HKEY_USERS\S-1-5-18\Software`,
+		RegistryPathShort: `HKU\S-1-5-18\Software`,
+		RegistryPathDrive: `HKUS:\S-1-5-18\Software`,
+	} {
+		if got := p.Format(form); got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	}
+}
+
+func TestFormatRegistryKeys(t *testing.T) {
+	r, err := AddRegistryValue("", "", `HKLM\Software\Contoso`, "Enabled", RegistryValueTypeDWord, "1", false, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := NewWingetCfg()
+	cfg.AddResource(r)
+
+	if err := cfg.FormatRegistryKeys(RegistryPathShort); err == nil {
+		t.Error("the short form is not supported by xRegistry and should be rejected")
+	}
+	if key := settingString(r.Settings, "Key"); key != `HKEY_LOCAL_MACHINE\Software\Contoso` {
+		t.Errorf("got key %s, want the long form", key)
+	}
+
+	if err := cfg.FormatRegistryKeys(RegistryPathDrive); err != nil {
+		t.Fatal(err)
+	}
+	if key := settingString(r.Settings, "Key"); key != `HKLM:\Software\Contoso` {
+		t.Errorf("got key %s, want HKLM:\\Software\\Contoso", key)
+	}
+}
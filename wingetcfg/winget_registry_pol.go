@@ -0,0 +1,331 @@
+package wingetcfg
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Registry.pol files start with the PReg signature followed by the version number
+const (
+	RegistryPolSignature        = "PReg"
+	RegistryPolVersion   uint32 = 1
+)
+
+// Registry value types as stored in Registry.pol files
+const (
+	regNone           uint32 = 0
+	regSZ             uint32 = 1
+	regExpandSZ       uint32 = 2
+	regBinary         uint32 = 3
+	regDWord          uint32 = 4
+	regDWordBigEndian uint32 = 5
+	regMultiSZ        uint32 = 7
+	regQWord          uint32 = 11
+)
+
+const registryPolImported = "Imported from Registry.pol"
+
+type registryPolEntry struct {
+	Key       string
+	ValueName string
+	Type      uint32
+	Data      []byte
+}
+
+// ReadRegistryPolFile reads a Group Policy Registry.pol file and converts its entries to xRegistry resources.
+// Hive is the hive where the policy is applied, usually HKEY_LOCAL_MACHINE for Machine\Registry.pol
+// and HKEY_CURRENT_USER for User\Registry.pol.
+func ReadRegistryPolFile(filePath string, hive RegistryHive) ([]*WinGetResource, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadRegistryPol(f, hive)
+}
+
+// ReadRegistryPol reads a Group Policy Registry.pol (PReg) file and converts each of its entries to xRegistry resources
+// under the specified hive. The following directives are supported:
+// **del.ValueName removes the value.
+// **delvals. removes all the values of the key with a script resource, the key, its subkeys and the values
+// that the following entries of the file write into it are kept.
+// **DeleteValues removes the values listed in the data separated by semicolons.
+// **DeleteKeys removes the subkeys listed in the data separated by semicolons.
+// **soft.ValueName sets the value without Force so an existing value is not overwritten.
+// **SecureKey is ignored as key permissions can't be managed with xRegistry.
+func ReadRegistryPol(r io.Reader, hive RegistryHive) ([]*WinGetResource, error) {
+	if _, ok := registryHiveAbbreviations[hive]; !ok {
+		return nil, fmt.Errorf("registry hive %s is not valid", hive)
+	}
+
+	entries, err := parseRegistryPol(r)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []*WinGetResource{}
+	// The **delvals. entries are converted once the values written after them are known,
+	// cleared maps their position in resources to the key
+	cleared := map[int]string{}
+	for _, entry := range entries {
+		if strings.HasPrefix(strings.ToLower(entry.ValueName), "**delvals.") {
+			cleared[len(resources)] = RegistryPath{Hive: hive, SubKey: entry.Key}.String()
+			resources = append(resources, nil)
+			continue
+		}
+
+		converted, err := registryPolEntryResources(entry, hive)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert Registry.pol entry %s\\%s: %v", entry.Key, entry.ValueName, err)
+		}
+		resources = append(resources, converted...)
+	}
+
+	for i, key := range cleared {
+		r, err := clearRegistryKeyResource(registryPolImported, key, resources[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("could not convert Registry.pol entry %s\\**delvals.: %v", key, err)
+		}
+		resources[i] = r
+	}
+
+	return resources, nil
+}
+
+// parseRegistryPol parses the binary format of a Registry.pol file, each entry is stored as
+// [key;value;type;size;data] where key and value are null terminated UTF-16LE strings,
+// type and size are little endian DWORDs and brackets and semicolons are UTF-16LE characters.
+// Reference: https://learn.microsoft.com/en-us/previous-versions/windows/desktop/policy/registry-policy-file-format
+func parseRegistryPol(r io.Reader) ([]registryPolEntry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 8 || string(data[:4]) != RegistryPolSignature {
+		return nil, errors.New("the file is not a valid Registry.pol file, PReg signature not found")
+	}
+	if version := binary.LittleEndian.Uint32(data[4:8]); version != RegistryPolVersion {
+		return nil, fmt.Errorf("Registry.pol version %d is not supported", version)
+	}
+
+	p := registryPolParser{data: data, offset: 8}
+	entries := []registryPolEntry{}
+	for p.offset < len(p.data) {
+		entry := registryPolEntry{}
+
+		if err := p.expect('['); err != nil {
+			return nil, err
+		}
+		if entry.Key, err = p.readString(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(';'); err != nil {
+			return nil, err
+		}
+		if entry.ValueName, err = p.readString(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(';'); err != nil {
+			return nil, err
+		}
+		if entry.Type, err = p.readUint32(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(';'); err != nil {
+			return nil, err
+		}
+		size, err := p.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(';'); err != nil {
+			return nil, err
+		}
+		if entry.Data, err = p.readBytes(int(size)); err != nil {
+			return nil, err
+		}
+		if err := p.expect(']'); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+type registryPolParser struct {
+	data   []byte
+	offset int
+}
+
+func (p *registryPolParser) readBytes(n int) ([]byte, error) {
+	if n < 0 || p.offset+n > len(p.data) {
+		return nil, fmt.Errorf("unexpected end of Registry.pol file at offset %d", p.offset)
+	}
+	b := p.data[p.offset : p.offset+n]
+	p.offset += n
+	return b, nil
+}
+
+func (p *registryPolParser) readUint16() (uint16, error) {
+	b, err := p.readBytes(2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(b), nil
+}
+
+func (p *registryPolParser) readUint32() (uint32, error) {
+	b, err := p.readBytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func (p *registryPolParser) expect(c rune) error {
+	offset := p.offset
+	v, err := p.readUint16()
+	if err != nil {
+		return err
+	}
+	if rune(v) != c {
+		return fmt.Errorf("unexpected character in Registry.pol file at offset %d, expected %q", offset, c)
+	}
+	return nil
+}
+
+// readString reads a null terminated UTF-16LE string
+func (p *registryPolParser) readString() (string, error) {
+	s := []uint16{}
+	for {
+		v, err := p.readUint16()
+		if err != nil {
+			return "", err
+		}
+		if v == 0 {
+			return string(utf16.Decode(s)), nil
+		}
+		s = append(s, v)
+	}
+}
+
+// registryPolEntryResources converts a Registry.pol entry to the xRegistry resources that produce the same change,
+// **delvals. entries are converted by ReadRegistryPol
+func registryPolEntryResources(entry registryPolEntry, hive RegistryHive) ([]*WinGetResource, error) {
+	key := RegistryPath{Hive: hive, SubKey: entry.Key}.String()
+	valueName := entry.ValueName
+	lowerName := strings.ToLower(valueName)
+
+	switch {
+	case strings.HasPrefix(lowerName, "**del."):
+		r, err := RemoveRegistryValue("", registryPolImported, key, valueName[len("**del."):])
+		if err != nil {
+			return nil, err
+		}
+		return []*WinGetResource{r}, nil
+
+	case lowerName == "**deletevalues", lowerName == "**deletekeys":
+		resources := []*WinGetResource{}
+		for _, item := range strings.Split(decodeRegistryPolString(entry.Data), ";") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+
+			var r *WinGetResource
+			var err error
+			if lowerName == "**deletevalues" {
+				r, err = RemoveRegistryValue("", registryPolImported, key, item)
+			} else {
+				r, err = RemoveRegistryKey("", registryPolImported, key+`\`+item, true)
+			}
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, r)
+		}
+		return resources, nil
+
+	case lowerName == "**securekey":
+		return nil, nil
+	}
+
+	force := true
+	if strings.HasPrefix(lowerName, "**soft.") {
+		valueName = valueName[len("**soft."):]
+		force = false
+	}
+
+	var r *WinGetResource
+	var err error
+	switch entry.Type {
+	case regNone:
+		if valueName != "" || len(entry.Data) > 0 {
+			return nil, errors.New("REG_NONE values are not supported by xRegistry")
+		}
+		r, err = AddRegistryKey("", registryPolImported, key)
+	case regSZ:
+		data := []string{}
+		if v := decodeRegistryPolString(entry.Data); v != "" {
+			data = append(data, v)
+		}
+		r, err = newWinGetRegistryResource("", registryPolImported, key, valueName, RegistryValueTypeString, data, EnsurePresent, false, force)
+	case regExpandSZ:
+		r, err = newWinGetRegistryResource("", registryPolImported, key, valueName, RegistryValueTypeExpandString, []string{decodeRegistryPolString(entry.Data)}, EnsurePresent, false, force)
+	case regBinary:
+		data := []string{}
+		if len(entry.Data) > 0 {
+			data = append(data, fmt.Sprintf("%x", entry.Data))
+		}
+		r, err = newWinGetRegistryResource("", registryPolImported, key, valueName, RegistryValueTypeBinary, data, EnsurePresent, false, force)
+	case regDWord, regDWordBigEndian:
+		if len(entry.Data) != 4 {
+			return nil, fmt.Errorf("DWord data must have 4 bytes, found %d", len(entry.Data))
+		}
+		v := binary.LittleEndian.Uint32(entry.Data)
+		if entry.Type == regDWordBigEndian {
+			v = binary.BigEndian.Uint32(entry.Data)
+		}
+		r, err = newWinGetRegistryResource("", registryPolImported, key, valueName, RegistryValueTypeDWord, []string{strconv.FormatUint(uint64(v), 10)}, EnsurePresent, false, force)
+	case regQWord:
+		if len(entry.Data) != 8 {
+			return nil, fmt.Errorf("QWord data must have 8 bytes, found %d", len(entry.Data))
+		}
+		v := binary.LittleEndian.Uint64(entry.Data)
+		r, err = newWinGetRegistryResource("", registryPolImported, key, valueName, RegistryValueTypeQWord, []string{strconv.FormatUint(v, 10)}, EnsurePresent, false, force)
+	case regMultiSZ:
+		data := []string{}
+		for _, s := range strings.Split(decodeRegistryPolString(entry.Data), "\x00") {
+			if s != "" {
+				data = append(data, s)
+			}
+		}
+		r, err = newWinGetRegistryResource("", registryPolImported, key, valueName, RegistryValueTypeMultistring, data, EnsurePresent, false, force)
+	default:
+		return nil, fmt.Errorf("registry value type %d is not supported", entry.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return []*WinGetResource{r}, nil
+}
+
+// decodeRegistryPolString decodes UTF-16LE data removing the trailing null characters
+func decodeRegistryPolString(data []byte) string {
+	u := make([]uint16, len(data)/2)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(data[2*i:])
+	}
+	return strings.TrimRight(string(utf16.Decode(u)), "\x00")
+}
@@ -0,0 +1,71 @@
+package wingetcfg
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadRegistryPolFile(t *testing.T) {
+	resources, err := ReadRegistryPolFile(filepath.Join("testdata", "registry", "Registry.pol"), RegistryHiveLocalMachine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) != 6 {
+		t.Fatalf("got %d resources, want 6", len(resources))
+	}
+
+	const key = `HKEY_LOCAL_MACHINE\Software\Policies\Contoso`
+	for i, r := range resources {
+		if r.Resource == WinGetRegistryResource && settingString(r.Settings, "Key") != key {
+			t.Errorf("resource %d: got key %s, want %s", i, settingString(r.Settings, "Key"), key)
+		}
+	}
+
+	before := resources[0]
+	if settingString(before.Settings, "ValueName") != "Before" || settingString(before.Settings, "ValueData") != "old" {
+		t.Errorf("unexpected resource for the String value: %v", before.Settings)
+	}
+
+	// **delvals. keeps the key and the values that the following entries write
+	clear := resources[1]
+	if clear.Resource != PSDscScriptResource {
+		t.Fatalf("**delvals. should be converted to a script resource, got %s", clear.Resource)
+	}
+	set := settingString(clear.Settings, "SetScript")
+	if !strings.Contains(set, "$keep = @('(default)', 'Enabled', 'Servers', 'Mode')") {
+		t.Errorf("the script should keep the values written after **delvals.:\n%s", set)
+	}
+	if strings.Contains(set, "Remove-Item ") || !strings.Contains(set, `Registry::HKEY_LOCAL_MACHINE\Software\Policies\Contoso`) {
+		t.Errorf("the script should only remove values of the key:\n%s", set)
+	}
+
+	enabled := resources[2]
+	if settingString(enabled.Settings, "ValueType") != RegistryValueTypeDWord || settingString(enabled.Settings, "ValueData") != "1" || enabled.Settings["Force"] != true {
+		t.Errorf("unexpected resource for the DWord value: %v", enabled.Settings)
+	}
+
+	servers := resources[3]
+	if data, _ := servers.Settings["ValueData"].([]string); settingString(servers.Settings, "ValueType") != RegistryValueTypeMultistring || !slices.Equal(data, []string{"a.contoso.com", "b.contoso.com"}) {
+		t.Errorf("unexpected resource for the MultiString value: %v", servers.Settings)
+	}
+
+	legacy := resources[4]
+	if settingString(legacy.Settings, "ValueName") != "Legacy" || settingString(legacy.Settings, "Ensure") != EnsureAbsent {
+		t.Errorf("**del. should remove the value: %v", legacy.Settings)
+	}
+
+	mode := resources[5]
+	if settingString(mode.Settings, "ValueName") != "Mode" || settingString(mode.Settings, "ValueData") != "auto" || mode.Settings["Force"] != nil {
+		t.Errorf("**soft. should set the value without Force: %v", mode.Settings)
+	}
+}
+
+func TestReadRegistryPolRejectsInvalidFiles(t *testing.T) {
+	for _, data := range []string{"", "PReg", "PReg\x02\x00\x00\x00", "PReg\x01\x00\x00\x00[\x00"} {
+		if _, err := ReadRegistryPol(strings.NewReader(data), RegistryHiveLocalMachine); err == nil {
+			t.Errorf("%q: expected an error", data)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package wingetcfg
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var registryEnvironmentVariable = regexp.MustCompile(`%[A-Za-z_][A-Za-z0-9_()]*%`)
+
+// normalizeRegistryValueData validates the data of a single string registry value according to its type
+// and returns the data in the form expected by xRegistry:
+// DWord and QWord decimal numbers must fit in 32 or 64 bits, unsigned numbers are converted to their signed
+// representation as xRegistry parses them as signed integers. Hexadecimal numbers are returned with the 0x prefix.
+// Binary data must be an even-length hexadecimal string.
+// String data is kept as is, see RegistryValueWarnings for the data that may need ExpandString.
+func normalizeRegistryValueData(name string, valueType string, data string, hex bool) (string, error) {
+	switch valueType {
+	case RegistryValueTypeDWord, RegistryValueTypeQWord:
+		bitSize := 32
+		if valueType == RegistryValueTypeQWord {
+			bitSize = 64
+		}
+
+		data = strings.TrimSpace(data)
+		if hex {
+			digits := strings.TrimPrefix(strings.TrimPrefix(data, "0x"), "0X")
+			v, err := strconv.ParseUint(digits, 16, bitSize)
+			if err != nil {
+				return "", fmt.Errorf("registry resource %s: ValueData %s is not a valid hexadecimal %s", name, data, valueType)
+			}
+			return fmt.Sprintf("0x%X", v), nil
+		}
+
+		v, err := parseRegistryNumber(data, false, bitSize)
+		if err != nil {
+			min, max := int64(math.MinInt32), uint64(math.MaxUint32)
+			if bitSize == 64 {
+				min, max = math.MinInt64, math.MaxUint64
+			}
+			return "", fmt.Errorf("registry resource %s: ValueData %s is not a valid %s, it must be a decimal number between %d and %d", name, data, valueType, min, max)
+		}
+
+		if bitSize == 32 {
+			return strconv.FormatInt(int64(int32(uint32(v))), 10), nil
+		}
+		return strconv.FormatInt(int64(v), 10), nil
+
+	case RegistryValueTypeBinary:
+		digits := strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(data), "0x"), "0X")
+		if len(digits)%2 != 0 {
+			return "", fmt.Errorf("registry resource %s: ValueData %s must have an even number of hexadecimal digits", name, data)
+		}
+		if !isHexString(digits) {
+			return "", fmt.Errorf("registry resource %s: ValueData %s is not a valid hexadecimal string", name, data)
+		}
+		return "0x" + strings.ToLower(digits), nil
+	}
+
+	return data, nil
+}
+
+// RegistryValueWarnings returns advice about a registry resource that is valid but may not do what's
+// expected, e.g. String data that references an environment variable like %VAR%, which is stored
+// literally unless the ExpandString type is used. Literal references are legitimate, so they're not errors.
+func RegistryValueWarnings(r *WinGetResource) []string {
+	warnings := []string{}
+	if r == nil || r.Resource != WinGetRegistryResource || settingString(r.Settings, "ValueType") != RegistryValueTypeString {
+		return warnings
+	}
+
+	if v := registryEnvironmentVariable.FindString(settingString(r.Settings, "ValueData")); v != "" {
+		name := registryResourceName(r.ID, settingString(r.Settings, "Key"), settingString(r.Settings, "ValueName"))
+		warnings = append(warnings, fmt.Sprintf("registry resource %s: ValueData references the environment variable %s, use the ExpandString type if it must be expanded", name, v))
+	}
+	return warnings
+}
+
+// registryResourceName identifies a registry resource in error messages
+func registryResourceName(ID string, key string, valueName string) string {
+	if ID != "" {
+		return ID
+	}
+	if valueName != "" {
+		return key + `\` + valueName
+	}
+	return key
+}
+
+func isHexString(s string) bool {
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
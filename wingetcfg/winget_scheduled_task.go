@@ -0,0 +1,342 @@
+package wingetcfg
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	WinGetScheduledTaskResource = "ComputerManagementDsc/ScheduledTask"
+)
+
+const (
+	ScheduleTypeOnce      string = "Once"
+	ScheduleTypeDaily     string = "Daily"
+	ScheduleTypeWeekly    string = "Weekly"
+	ScheduleTypeAtStartup string = "AtStartup"
+	ScheduleTypeAtLogOn   string = "AtLogOn"
+)
+
+const (
+	TaskRunLevelLimited string = "Limited"
+	TaskRunLevelHighest string = "Highest"
+)
+
+const (
+	TaskAccountSystem         string = "SYSTEM"
+	TaskAccountLocalService   string = "LOCAL SERVICE"
+	TaskAccountNetworkService string = "NETWORK SERVICE"
+)
+
+// ScheduledTaskSchedule is the trigger of a scheduled task.
+// Durations are ISO-8601 durations with weeks, days, hours, minutes and seconds (e.g. PT15M or P1DT12H)
+// or TimeSpan strings (e.g. 00:15:00 or 1.12:00:00), they're written as TimeSpan strings.
+type ScheduledTaskSchedule struct {
+	// Type is Once, Daily, Weekly, AtStartup or AtLogOn
+	Type string
+	// StartTime is an ISO-8601 local date and time, e.g. 2024-01-01T02:00:00. Required for Once, Daily and Weekly.
+	StartTime string
+	// RepeatInterval is how often the task is repeated after it's triggered, between 1 minute and 31 days
+	RepeatInterval string
+	// RepetitionDuration is how long the task is repeated, it must not be shorter than RepeatInterval.
+	// If empty the task is repeated indefinitely.
+	RepetitionDuration string
+	// DaysInterval is the number of days between runs of Daily tasks
+	DaysInterval int
+	// WeeksInterval is the number of weeks between runs of Weekly tasks
+	WeeksInterval int
+	// DaysOfWeek are the days Weekly tasks run on, e.g. Monday
+	DaysOfWeek []string
+	// User is the account whose log on triggers AtLogOn tasks, if empty any user triggers it
+	User string
+}
+
+var iso8601Duration = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+var timeSpanDuration = regexp.MustCompile(`^(?:(\d+)\.)?(\d{1,2}):(\d{2})(?::(\d{2}))?$`)
+
+// ISO-8601 layouts accepted for the start time, without time zone the task runs in the local time of the endpoint
+var taskTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04"}
+
+var weekDays = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+// RemoveScheduledTask removes a scheduled task.
+// ID is an optional identifier.
+// TaskName is required and is the name of the task.
+// TaskPath is the folder of the task, if empty the root folder \ is used.
+func RemoveScheduledTask(ID, taskName string, taskPath string) (*WinGetResource, error) {
+	return NewScheduledTaskResource(ID, "", taskName, taskPath, "", "", "", ScheduledTaskSchedule{}, "", "", "", "", false, EnsureAbsent)
+}
+
+// NewScheduledTaskResource creates a new WinGetResource that contains the settings to manage a scheduled task.
+// ID is an optional identifier.
+// Description is an optional text that describes the task.
+// TaskName is required and is the name of the task.
+// TaskPath is the folder of the task, it must start and end with \. If empty the root folder \ is used.
+// ActionExecutable is the program to run, it's required when Ensure is Present.
+// ActionArguments are the optional arguments of the program.
+// ActionWorkingPath is the optional working directory of the program.
+// Schedule is the trigger of the task, see ScheduledTaskSchedule.
+// RunLevel is Limited or Highest, Highest runs the task elevated.
+// BuiltInAccount is the account the task runs as: SYSTEM, LOCAL SERVICE or NETWORK SERVICE.
+// Username and Password are the credential of the account the task runs as (ExecuteAsCredential),
+// they cannot be used with BuiltInAccount.
+// Enable specifies whether the task is enabled.
+// Ensure specifies whether the task should exist.
+// Reference: https://github.com/dsccommunity/ComputerManagementDsc/blob/main/source/DSCResources/DSC_ScheduledTask/DSC_ScheduledTask.psm1
+func NewScheduledTaskResource(ID, description string, taskName string, taskPath string, actionExecutable string, actionArguments string, actionWorkingPath string, schedule ScheduledTaskSchedule, runLevel string, builtInAccount string, username string, password string, enable bool, ensure string) (*WinGetResource, error) {
+	r := WinGetResource{}
+	r.Resource = WinGetScheduledTaskResource
+
+	// ID (optional)
+	if ID != "" {
+		r.ID = ID
+	}
+
+	// Directives
+	r.Directives.Description = description
+	r.Directives.AllowPreRelease = true
+
+	// Settings
+	r.Settings = map[string]any{}
+
+	if taskName == "" {
+		return nil, errors.New("taskName cannot be empty")
+	}
+	r.Settings["TaskName"] = taskName
+
+	if taskPath == "" {
+		taskPath = `\`
+	}
+	if !strings.HasPrefix(taskPath, `\`) || !strings.HasSuffix(taskPath, `\`) {
+		return nil, fmt.Errorf(`taskPath %s must start and end with \`, taskPath)
+	}
+	r.Settings["TaskPath"] = taskPath
+
+	ensure = SetEnsureValue(ensure)
+	r.Settings["Ensure"] = ensure
+	if ensure == EnsureAbsent {
+		return &r, nil
+	}
+
+	if description != "" {
+		r.Settings["Description"] = description
+	}
+
+	if actionExecutable == "" {
+		return nil, errors.New("actionExecutable cannot be empty")
+	}
+	r.Settings["ActionExecutable"] = actionExecutable
+
+	if actionArguments != "" {
+		r.Settings["ActionArguments"] = actionArguments
+	}
+
+	if actionWorkingPath != "" {
+		r.Settings["ActionWorkingPath"] = actionWorkingPath
+	}
+
+	if err := setTaskSchedule(&r, schedule); err != nil {
+		return nil, err
+	}
+
+	switch runLevel {
+	case "":
+	case TaskRunLevelLimited, TaskRunLevelHighest:
+		r.Settings["RunLevel"] = runLevel
+	default:
+		return nil, fmt.Errorf("run level %s is not valid, use Limited or Highest", runLevel)
+	}
+
+	switch builtInAccount {
+	case "":
+	case TaskAccountSystem, TaskAccountLocalService, TaskAccountNetworkService:
+		if username != "" {
+			return nil, errors.New("builtInAccount and username cannot be used together")
+		}
+		r.Settings["BuiltInAccount"] = builtInAccount
+	default:
+		return nil, fmt.Errorf("built-in account %s is not valid, use SYSTEM, LOCAL SERVICE or NETWORK SERVICE", builtInAccount)
+	}
+
+	if username != "" {
+		if password == "" && !strings.HasSuffix(username, "$") {
+			return nil, errors.New("password cannot be empty")
+		}
+		r.Settings["ExecuteAsCredential"] = map[string]any{
+			"UserName": username,
+			"Password": password,
+		}
+	} else if password != "" {
+		return nil, errors.New("username cannot be empty if a password is set")
+	}
+
+	r.Settings["Enable"] = enable
+
+	return &r, nil
+}
+
+func setTaskSchedule(r *WinGetResource, schedule ScheduledTaskSchedule) error {
+	switch schedule.Type {
+	case ScheduleTypeOnce, ScheduleTypeDaily, ScheduleTypeWeekly, ScheduleTypeAtStartup, ScheduleTypeAtLogOn:
+		r.Settings["ScheduleType"] = schedule.Type
+	default:
+		return fmt.Errorf("schedule type %s is not valid, use Once, Daily, Weekly, AtStartup or AtLogOn", schedule.Type)
+	}
+
+	switch schedule.Type {
+	case ScheduleTypeOnce, ScheduleTypeDaily, ScheduleTypeWeekly:
+		if schedule.StartTime == "" {
+			return fmt.Errorf("startTime cannot be empty for %s tasks", schedule.Type)
+		}
+	}
+
+	if schedule.StartTime != "" {
+		if !isTaskTime(schedule.StartTime) {
+			return fmt.Errorf("startTime %s is not a valid ISO-8601 date and time, e.g. 2024-01-01T02:00:00", schedule.StartTime)
+		}
+		r.Settings["StartTime"] = schedule.StartTime
+	}
+
+	if schedule.RepeatInterval != "" {
+		interval, err := parseTaskDuration(schedule.RepeatInterval)
+		if err != nil {
+			return fmt.Errorf("repeatInterval: %v", err)
+		}
+		if interval < time.Minute || interval > 31*24*time.Hour {
+			return fmt.Errorf("repeatInterval %s must be between 1 minute and 31 days", schedule.RepeatInterval)
+		}
+		r.Settings["RepeatInterval"] = formatTimeSpan(interval)
+
+		if schedule.RepetitionDuration != "" {
+			duration, err := parseTaskDuration(schedule.RepetitionDuration)
+			if err != nil {
+				return fmt.Errorf("repetitionDuration: %v", err)
+			}
+			if duration < interval {
+				return fmt.Errorf("repetitionDuration %s cannot be shorter than repeatInterval %s", schedule.RepetitionDuration, schedule.RepeatInterval)
+			}
+			r.Settings["RepetitionDuration"] = formatTimeSpan(duration)
+		} else {
+			r.Settings["RepetitionDuration"] = "Indefinitely"
+		}
+	} else if schedule.RepetitionDuration != "" {
+		return errors.New("repetitionDuration requires a repeatInterval")
+	}
+
+	if schedule.DaysInterval != 0 {
+		if schedule.Type != ScheduleTypeDaily || schedule.DaysInterval < 0 {
+			return errors.New("daysInterval must be a positive number of days and only applies to Daily tasks")
+		}
+		r.Settings["DaysInterval"] = schedule.DaysInterval
+	}
+
+	if schedule.WeeksInterval != 0 {
+		if schedule.Type != ScheduleTypeWeekly || schedule.WeeksInterval < 0 {
+			return errors.New("weeksInterval must be a positive number of weeks and only applies to Weekly tasks")
+		}
+		r.Settings["WeeksInterval"] = schedule.WeeksInterval
+	}
+
+	if schedule.Type == ScheduleTypeWeekly && len(schedule.DaysOfWeek) == 0 {
+		return errors.New("daysOfWeek cannot be empty for Weekly tasks")
+	}
+	if len(schedule.DaysOfWeek) > 0 {
+		if schedule.Type != ScheduleTypeWeekly {
+			return errors.New("daysOfWeek only applies to Weekly tasks")
+		}
+		days := []string{}
+		for _, day := range schedule.DaysOfWeek {
+			name, ok := weekDay(day)
+			if !ok {
+				return fmt.Errorf("day of week %s is not valid", day)
+			}
+			days = append(days, name)
+		}
+		r.Settings["DaysOfWeek"] = days
+	}
+
+	if schedule.User != "" {
+		if schedule.Type != ScheduleTypeAtLogOn {
+			return errors.New("user only applies to AtLogOn tasks")
+		}
+		r.Settings["User"] = schedule.User
+	}
+
+	return nil
+}
+
+// maxTaskDuration is the longest duration accepted for the repetition of a task, longer durations
+// make no sense for a schedule and would overflow time.Duration, that is limited to about 292 years
+const maxTaskDuration = 100 * 365 * 24 * time.Hour
+
+// parseTaskDuration parses an ISO-8601 duration (e.g. PT15M) or a TimeSpan string (e.g. 00:15:00).
+// Years and months are not accepted as their length is not fixed. Durations longer than maxTaskDuration,
+// about 100 years, are rejected.
+func parseTaskDuration(s string) (time.Duration, error) {
+	var parts []string
+	var units []time.Duration
+
+	if m := iso8601Duration.FindStringSubmatch(s); m != nil && s != "P" && !strings.HasSuffix(s, "T") {
+		parts = m[1:]
+		units = []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	} else if m := timeSpanDuration.FindStringSubmatch(s); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		minutes, _ := strconv.Atoi(m[3])
+		seconds, _ := strconv.Atoi("0" + m[4])
+		if hours > 23 || minutes > 59 || seconds > 59 {
+			return 0, fmt.Errorf("duration %s is not a valid TimeSpan", s)
+		}
+		parts = m[1:]
+		units = []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second}
+	} else {
+		return 0, fmt.Errorf("duration %s is not a valid ISO-8601 duration, e.g. PT15M, or TimeSpan, e.g. 00:15:00", s)
+	}
+
+	// Each part is checked against the remaining room so the sum never overflows
+	var d time.Duration
+	for i, unit := range units {
+		if parts[i] == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(parts[i], 10, 64)
+		if err != nil || n > int64((maxTaskDuration-d)/unit) {
+			return 0, fmt.Errorf("duration %s is too long, the maximum is %s", s, formatTimeSpan(maxTaskDuration))
+		}
+		d += time.Duration(n) * unit
+	}
+	return d, nil
+}
+
+// formatTimeSpan writes a duration as a .NET TimeSpan string, e.g. 1.12:00:00
+func formatTimeSpan(d time.Duration) string {
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	span := fmt.Sprintf("%02d:%02d:%02d", d/time.Hour, (d%time.Hour)/time.Minute, (d%time.Minute)/time.Second)
+	if days > 0 {
+		return fmt.Sprintf("%d.%s", days, span)
+	}
+	return span
+}
+
+func isTaskTime(s string) bool {
+	for _, layout := range taskTimeLayouts {
+		if _, err := time.Parse(layout, s); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func weekDay(day string) (string, bool) {
+	for _, name := range weekDays {
+		if strings.EqualFold(name, day) {
+			return name, true
+		}
+	}
+	return "", false
+}
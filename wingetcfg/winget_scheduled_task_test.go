@@ -0,0 +1,45 @@
+package wingetcfg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTaskDuration(t *testing.T) {
+	tests := []struct {
+		duration string
+		want     time.Duration
+	}{
+		{"PT15M", 15 * time.Minute},
+		{"P1DT12H", 36 * time.Hour},
+		{"P2W", 14 * 24 * time.Hour},
+		{"00:15:00", 15 * time.Minute},
+		{"1.12:00:00", 36 * time.Hour},
+		{"36500.00:00:00", 36500 * 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := parseTaskDuration(tt.duration)
+		if err != nil {
+			t.Errorf("%s: %v", tt.duration, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.duration, got, tt.want)
+		}
+	}
+}
+
+func TestParseTaskDurationRejectsOverflow(t *testing.T) {
+	for _, duration := range []string{
+		"P40000W",
+		"PT9223372036854775807S",
+		"PT99999999999999999999S",
+		"P5000WT999999999H",
+		"40000.00:00:00",
+		"99999999999999999999.00:00:00",
+	} {
+		if d, err := parseTaskDuration(duration); err == nil {
+			t.Errorf("%s: got %s, want an error", duration, formatTimeSpan(d))
+		}
+	}
+}
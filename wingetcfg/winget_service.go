@@ -0,0 +1,160 @@
+package wingetcfg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	WinGetServiceResource = "xPSDesiredStateConfiguration/xService"
+)
+
+const (
+	ServiceStateRunning string = "Running"
+	ServiceStateStopped string = "Stopped"
+	ServiceStateIgnore  string = "Ignore"
+)
+
+const (
+	ServiceStartupTypeAutomatic string = "Automatic"
+	ServiceStartupTypeManual    string = "Manual"
+	ServiceStartupTypeDisabled  string = "Disabled"
+)
+
+const (
+	ServiceAccountLocalSystem    string = "LocalSystem"
+	ServiceAccountLocalService   string = "LocalService"
+	ServiceAccountNetworkService string = "NetworkService"
+)
+
+// ConfigureService sets the state and startup type of an existing service.
+// ID is an optional identifier.
+// Name is required and is the name of the service, not its display name.
+// State is Running, Stopped or Ignore.
+// StartupType is Automatic, Manual or Disabled, it's left unchanged if empty.
+func ConfigureService(ID, name string, state string, startupType string) (*WinGetResource, error) {
+	return NewServiceResource(ID, name, "", "", "", state, startupType, "", "", "", nil, EnsurePresent)
+}
+
+// DisableService stops a service and prevents it from starting again.
+// ID is an optional identifier.
+// Name is required and is the name of the service, not its display name.
+func DisableService(ID, name string) (*WinGetResource, error) {
+	return NewServiceResource(ID, name, "", "", "", ServiceStateStopped, ServiceStartupTypeDisabled, "", "", "", nil, EnsurePresent)
+}
+
+// RemoveService stops and deletes a service.
+// ID is an optional identifier.
+// Name is required and is the name of the service, not its display name.
+func RemoveService(ID, name string) (*WinGetResource, error) {
+	return NewServiceResource(ID, name, "", "", "", "", "", "", "", "", nil, EnsureAbsent)
+}
+
+// NewServiceResource creates a new WinGetResource that contains the settings to manage a Windows service.
+// ID is an optional identifier.
+// Name is required and is the name of the service, not its display name.
+// DisplayName is optional and is the name shown in the services console.
+// Description is an optional text that describes the service.
+// Path is the path to the service executable, it's required to create a new service.
+// State is Running, Stopped or Ignore. If empty the resource's default, Running, is used.
+// StartupType is Automatic, Manual or Disabled, it's left unchanged if empty.
+// BuiltInAccount is the account the service runs as: LocalSystem, LocalService or NetworkService.
+// Username and Password are the credential of the account the service runs as, they
+// cannot be used with BuiltInAccount. Group managed service accounts, ending in $, have no password.
+// Dependencies are the names of the services that must be running before this service starts.
+// Ensure specifies whether the service should exist. When Absent only the name is used.
+// Reference: https://github.com/dsccommunity/xPSDesiredStateConfiguration/blob/main/source/DSCResources/DSC_xServiceResource/DSC_xServiceResource.psm1
+func NewServiceResource(ID, name string, displayName string, description string, path string, state string, startupType string, builtInAccount string, username string, password string, dependencies []string, ensure string) (*WinGetResource, error) {
+	r := WinGetResource{}
+	r.Resource = WinGetServiceResource
+
+	// ID (optional)
+	if ID != "" {
+		r.ID = ID
+	}
+
+	// Directives
+	r.Directives.Description = description
+	r.Directives.AllowPreRelease = true
+
+	// Settings
+	r.Settings = map[string]any{}
+
+	if name == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+	r.Settings["Name"] = name
+
+	r.Settings["Ensure"] = SetEnsureValue(ensure)
+	if r.Settings["Ensure"] == EnsureAbsent {
+		return &r, nil
+	}
+
+	if displayName != "" {
+		r.Settings["DisplayName"] = displayName
+	}
+
+	if description != "" {
+		r.Settings["Description"] = description
+	}
+
+	if path != "" {
+		r.Settings["Path"] = path
+	}
+
+	switch state {
+	case "":
+	case ServiceStateRunning, ServiceStateStopped, ServiceStateIgnore:
+		r.Settings["State"] = state
+	default:
+		return nil, fmt.Errorf("service state %s is not valid, use Running, Stopped or Ignore", state)
+	}
+
+	switch startupType {
+	case "":
+	case ServiceStartupTypeAutomatic, ServiceStartupTypeManual, ServiceStartupTypeDisabled:
+		r.Settings["StartupType"] = startupType
+	default:
+		return nil, fmt.Errorf("service startup type %s is not valid, use Automatic, Manual or Disabled", startupType)
+	}
+
+	// The resource refuses to start a disabled service
+	if startupType == ServiceStartupTypeDisabled && state == ServiceStateRunning {
+		return nil, errors.New("a disabled service cannot be running")
+	}
+
+	switch builtInAccount {
+	case "":
+	case ServiceAccountLocalSystem, ServiceAccountLocalService, ServiceAccountNetworkService:
+		if username != "" {
+			return nil, errors.New("builtInAccount and username cannot be used together")
+		}
+		r.Settings["BuiltInAccount"] = builtInAccount
+	default:
+		return nil, fmt.Errorf("built-in account %s is not valid, use LocalSystem, LocalService or NetworkService", builtInAccount)
+	}
+
+	if username != "" {
+		if password == "" && !strings.HasSuffix(username, "$") {
+			return nil, errors.New("password cannot be empty")
+		}
+		r.Settings["Credential"] = map[string]any{
+			"UserName": username,
+			"Password": password,
+		}
+	} else if password != "" {
+		return nil, errors.New("username cannot be empty if a password is set")
+	}
+
+	if len(dependencies) > 0 {
+		for _, dependency := range dependencies {
+			if dependency == "" {
+				return nil, errors.New("dependencies cannot have empty service names")
+			}
+		}
+		r.Settings["Dependencies"] = dependencies
+	}
+
+	return &r, nil
+}
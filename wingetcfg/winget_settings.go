@@ -0,0 +1,229 @@
+package wingetcfg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	WinGetUserSettingsResource  = "Microsoft.WinGet.DSC/WinGetUserSettings"
+	WinGetAdminSettingsResource = "Microsoft.WinGet.DSC/WinGetAdminSettings"
+)
+
+const (
+	// SettingsActionPartial merges the settings with the ones in settings.json
+	SettingsActionPartial string = "Partial"
+	// SettingsActionFull replaces settings.json with the settings
+	SettingsActionFull string = "Full"
+)
+
+// WinGetSettings models winget's settings.json, only the fields that are set are written.
+// Reference: https://github.com/microsoft/winget-cli/blob/master/doc/Settings.md
+type WinGetSettings struct {
+	Visual               *WinGetVisualSettings            `json:"visual,omitempty"`
+	InstallBehavior      *WinGetInstallBehaviorSettings   `json:"installBehavior,omitempty"`
+	UninstallBehavior    *WinGetUninstallBehaviorSettings `json:"uninstallBehavior,omitempty"`
+	Source               *WinGetSourceSettings            `json:"source,omitempty"`
+	Network              *WinGetNetworkSettings           `json:"network,omitempty"`
+	Logging              *WinGetLoggingSettings           `json:"logging,omitempty"`
+	Telemetry            *WinGetTelemetrySettings         `json:"telemetry,omitempty"`
+	ExperimentalFeatures map[string]bool                  `json:"experimentalFeatures,omitempty"`
+}
+
+type WinGetVisualSettings struct {
+	// ProgressBar is accent, rainbow, retro, sixel or disabled
+	ProgressBar             string `json:"progressBar,omitempty"`
+	AnonymizeDisplayedPaths *bool  `json:"anonymizeDisplayedPaths,omitempty"`
+	EnableSixels            *bool  `json:"enableSixels,omitempty"`
+}
+
+type WinGetInstallBehaviorSettings struct {
+	DisableInstallNotes        *bool  `json:"disableInstallNotes,omitempty"`
+	PortablePackageUserRoot    string `json:"portablePackageUserRoot,omitempty"`
+	PortablePackageMachineRoot string `json:"portablePackageMachineRoot,omitempty"`
+	SkipDependencies           *bool  `json:"skipDependencies,omitempty"`
+	DefaultInstallRoot         string `json:"defaultInstallRoot,omitempty"`
+	// ArchiveExtractionMethod is shellApi or tar
+	ArchiveExtractionMethod string                    `json:"archiveExtractionMethod,omitempty"`
+	Preferences             *WinGetInstallerSelection `json:"preferences,omitempty"`
+	Requirements            *WinGetInstallerSelection `json:"requirements,omitempty"`
+}
+
+// WinGetInstallerSelection are the preferences or requirements used to choose an installer
+type WinGetInstallerSelection struct {
+	// Scope is user or machine
+	Scope          string   `json:"scope,omitempty"`
+	Locale         []string `json:"locale,omitempty"`
+	Architectures  []string `json:"architectures,omitempty"`
+	InstallerTypes []string `json:"installerTypes,omitempty"`
+}
+
+type WinGetUninstallBehaviorSettings struct {
+	PurgePortablePackage *bool `json:"purgePortablePackage,omitempty"`
+}
+
+type WinGetSourceSettings struct {
+	AutoUpdateIntervalInMinutes *int `json:"autoUpdateIntervalInMinutes,omitempty"`
+}
+
+type WinGetNetworkSettings struct {
+	// Downloader is default, wininet or do (Delivery Optimization)
+	Downloader                 string `json:"downloader,omitempty"`
+	DOProgressTimeoutInSeconds *int   `json:"doProgressTimeoutInSeconds,omitempty"`
+}
+
+type WinGetLoggingSettings struct {
+	// Level is verbose, info, warning, error or critical
+	Level string `json:"level,omitempty"`
+}
+
+type WinGetTelemetrySettings struct {
+	Disable *bool `json:"disable,omitempty"`
+}
+
+// WinGetAdminSettings models the settings that can only be changed by an administrator, only the fields that are set are written
+type WinGetAdminSettings struct {
+	LocalManifestFiles                        *bool `json:"LocalManifestFiles,omitempty"`
+	BypassCertificatePinningForMicrosoftStore *bool `json:"BypassCertificatePinningForMicrosoftStore,omitempty"`
+	InstallerHashOverride                     *bool `json:"InstallerHashOverride,omitempty"`
+	LocalArchiveMalwareScanOverride           *bool `json:"LocalArchiveMalwareScanOverride,omitempty"`
+	ProxyCommandLineOptions                   *bool `json:"ProxyCommandLineOptions,omitempty"`
+}
+
+// NewWinGetUserSettingsResource creates a new WinGetResource that configures winget's settings.json for the user running the configuration.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// Settings are required, only the fields that are set are written.
+// Action is Partial, to merge the settings with the current ones, or Full, to replace them. If empty Partial is used.
+// Reference: https://github.com/microsoft/winget-cli/blob/master/src/PowerShell/Microsoft.WinGet.DSC/Microsoft.WinGet.DSC.psm1
+func NewWinGetUserSettingsResource(ID string, description string, settings *WinGetSettings, action string) (*WinGetResource, error) {
+	if settings == nil {
+		return nil, errors.New("settings cannot be empty")
+	}
+	if err := settings.validate(); err != nil {
+		return nil, err
+	}
+
+	switch action {
+	case "":
+		action = SettingsActionPartial
+	case SettingsActionPartial, SettingsActionFull:
+	default:
+		return nil, fmt.Errorf("settings action %s is not valid, use Partial or Full", action)
+	}
+
+	r, err := newWinGetSettingsResource(WinGetUserSettingsResource, ID, description, settings)
+	if err != nil {
+		return nil, err
+	}
+	r.Settings["Action"] = action
+
+	return r, nil
+}
+
+// NewWinGetAdminSettingsResource creates a new WinGetResource that configures winget's admin settings, it requires elevation.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// Settings are required, only the fields that are set are changed.
+// Reference: https://github.com/microsoft/winget-cli/blob/master/src/PowerShell/Microsoft.WinGet.DSC/Microsoft.WinGet.DSC.psm1
+func NewWinGetAdminSettingsResource(ID string, description string, settings *WinGetAdminSettings) (*WinGetResource, error) {
+	if settings == nil {
+		return nil, errors.New("settings cannot be empty")
+	}
+	return newWinGetSettingsResource(WinGetAdminSettingsResource, ID, description, settings)
+}
+
+func newWinGetSettingsResource(resource string, ID string, description string, settings any) (*WinGetResource, error) {
+	r := WinGetResource{}
+	r.Resource = resource
+
+	// ID (optional)
+	if ID != "" {
+		r.ID = ID
+	}
+
+	// Directives
+	r.Directives.Description = description
+	r.Directives.AllowPreRelease = true
+
+	// Settings, the struct is converted to a map so it's written with the settings.json names
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]any{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, errors.New("settings cannot be empty")
+	}
+
+	r.Settings = map[string]any{}
+	r.Settings["Settings"] = values
+
+	return &r, nil
+}
+
+func (s *WinGetSettings) validate() error {
+	if s.Visual != nil {
+		if err := validateSettingValue("visual.progressBar", s.Visual.ProgressBar, "accent", "rainbow", "retro", "sixel", "disabled"); err != nil {
+			return err
+		}
+	}
+
+	if s.InstallBehavior != nil {
+		if err := validateSettingValue("installBehavior.archiveExtractionMethod", s.InstallBehavior.ArchiveExtractionMethod, "shellApi", "tar"); err != nil {
+			return err
+		}
+		for name, selection := range map[string]*WinGetInstallerSelection{"preferences": s.InstallBehavior.Preferences, "requirements": s.InstallBehavior.Requirements} {
+			if selection == nil {
+				continue
+			}
+			if err := validateSettingValue("installBehavior."+name+".scope", selection.Scope, "user", "machine"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.Source != nil && s.Source.AutoUpdateIntervalInMinutes != nil && *s.Source.AutoUpdateIntervalInMinutes < 0 {
+		return errors.New("source.autoUpdateIntervalInMinutes cannot be negative")
+	}
+
+	if s.Network != nil {
+		if err := validateSettingValue("network.downloader", s.Network.Downloader, "default", "wininet", "do"); err != nil {
+			return err
+		}
+		if s.Network.DOProgressTimeoutInSeconds != nil && (*s.Network.DOProgressTimeoutInSeconds < 1 || *s.Network.DOProgressTimeoutInSeconds > 600) {
+			return errors.New("network.doProgressTimeoutInSeconds must be between 1 and 600")
+		}
+	}
+
+	if s.Logging != nil {
+		if err := validateSettingValue("logging.level", s.Logging.Level, "verbose", "info", "warning", "error", "critical"); err != nil {
+			return err
+		}
+	}
+
+	for feature := range s.ExperimentalFeatures {
+		if feature == "" {
+			return errors.New("experimentalFeatures cannot have empty names")
+		}
+	}
+
+	return nil
+}
+
+// validateSettingValue checks that a setting is empty or one of the allowed values
+func validateSettingValue(name string, value string, allowed ...string) error {
+	if value == "" {
+		return nil
+	}
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s value %s is not valid, use one of %v", name, value, allowed)
+}
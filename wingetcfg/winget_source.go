@@ -0,0 +1,191 @@
+package wingetcfg
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const WinGetSourceResource = "Microsoft.WinGet.DSC/WinGetSource"
+
+const (
+	SourceTypeRest       string = "Microsoft.Rest"
+	SourceTypePreIndexed string = "Microsoft.PreIndexed.Package"
+)
+
+const (
+	SourceTrustLevelUndefined string = "Undefined"
+	SourceTrustLevelNone      string = "None"
+	SourceTrustLevelTrusted   string = "Trusted"
+)
+
+// DefaultSources are the sources configured by WinGet out of the box, packages from them don't need a WinGetSource resource
+var DefaultSources = []string{"winget", "msstore"}
+
+// AddSource adds a REST source, like a private source hosted with winget-cli-restsource.
+// ID is an optional identifier.
+// Name is required and is the name packages use to reference the source.
+// SourceURL is required and is the address of the source.
+// TrustLevel is Undefined, None or Trusted.
+func AddSource(ID, name string, sourceURL string, trustLevel string) (*WinGetResource, error) {
+	return NewWinGetSourceResource(ID, "", name, sourceURL, SourceTypeRest, trustLevel, false, EnsurePresent)
+}
+
+// RemoveSource removes a source.
+// ID is an optional identifier.
+// Name is required and is the name of the source.
+func RemoveSource(ID, name string) (*WinGetResource, error) {
+	return NewWinGetSourceResource(ID, "", name, "", "", "", false, EnsureAbsent)
+}
+
+// NewWinGetSourceResource creates a new WinGetResource that contains the settings to manage a WinGet source.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// Name is required and is the name packages use to reference the source.
+// Argument is the URL of the source, it's required when Ensure is Present.
+// SourceType is Microsoft.Rest or Microsoft.PreIndexed.Package, if empty WinGet's default, Microsoft.PreIndexed.Package, is used.
+// TrustLevel is Undefined, None or Trusted, if empty it's left as Undefined.
+// Explicit specifies whether the source is only used when a package references it by name.
+// Ensure specifies whether the source should exist.
+// Reference: https://github.com/microsoft/winget-cli/blob/master/src/PowerShell/Microsoft.WinGet.DSC/Microsoft.WinGet.DSC.psm1
+func NewWinGetSourceResource(ID string, description string, name string, argument string, sourceType string, trustLevel string, explicit bool, ensure string) (*WinGetResource, error) {
+	r := WinGetResource{}
+	r.Resource = WinGetSourceResource
+
+	// ID (optional)
+	if ID != "" {
+		r.ID = ID
+	}
+
+	// Directives
+	r.Directives.Description = description
+	r.Directives.AllowPreRelease = true
+
+	// Settings
+	r.Settings = map[string]any{}
+
+	if name == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+	r.Settings["Name"] = name
+
+	ensure = SetEnsureValue(ensure)
+	r.Settings["Ensure"] = ensure
+	if ensure == EnsureAbsent {
+		return &r, nil
+	}
+
+	if argument == "" {
+		return nil, errors.New("argument cannot be empty")
+	}
+	if u, err := url.Parse(argument); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("argument %s is not a valid URL", argument)
+	}
+	r.Settings["Argument"] = argument
+
+	switch sourceType {
+	case "":
+	case SourceTypeRest, SourceTypePreIndexed:
+		r.Settings["Type"] = sourceType
+	default:
+		return nil, fmt.Errorf("source type %s is not valid, use Microsoft.Rest or Microsoft.PreIndexed.Package", sourceType)
+	}
+
+	switch trustLevel {
+	case "":
+	case SourceTrustLevelUndefined, SourceTrustLevelNone, SourceTrustLevelTrusted:
+		r.Settings["TrustLevel"] = trustLevel
+	default:
+		return nil, fmt.Errorf("source trust level %s is not valid, use Undefined, None or Trusted", trustLevel)
+	}
+
+	r.Settings["Explicit"] = explicit
+
+	return &r, nil
+}
+
+// AddPackageSources makes the packages that reference a source that is not one of the DefaultSources
+// depend on a WinGetSource resource for it, the source is added to the dependencies they already have.
+// Sources are looked up first in the configuration and then in the given WinGetSource resources, copies
+// of them are added before the packages when they're needed. Sources without ID get WinGetSource-<name>
+// as ID. The configuration is only changed if all the packages can be resolved. Call it after all the
+// packages have been added.
+func (cfg *WinGetCfg) AddPackageSources(sources ...*WinGetResource) error {
+	available := map[string]*WinGetResource{}
+	for _, r := range sources {
+		if r == nil || r.Resource != WinGetSourceResource {
+			return errors.New("sources must be WinGetSource resources")
+		}
+		available[strings.ToLower(settingString(r.Settings, "Name"))] = r
+	}
+
+	configured := map[string]*WinGetResource{}
+	for _, r := range cfg.Properties.Resources {
+		if r != nil && r.Resource == WinGetSourceResource && settingString(r.Settings, "Ensure") != EnsureAbsent {
+			configured[strings.ToLower(settingString(r.Settings, "Name"))] = r
+		}
+	}
+
+	// The changes are collected first and applied once all the packages have been validated
+	injected := []*WinGetResource{}
+	sourceIDs := map[*WinGetResource]string{}
+	dependencies := map[*WinGetResource]string{}
+	for _, r := range cfg.Properties.Resources {
+		if r == nil || r.Resource != WinGetPackageResource {
+			continue
+		}
+
+		name := settingString(r.Settings, "source")
+		if name == "" || isDefaultSource(name) {
+			continue
+		}
+
+		source, ok := configured[strings.ToLower(name)]
+		if !ok {
+			provided, ok := available[strings.ToLower(name)]
+			if !ok {
+				return fmt.Errorf("package %s references source %s that has not been provided", settingString(r.Settings, "id"), name)
+			}
+			if settingString(provided.Settings, "Ensure") == EnsureAbsent {
+				return fmt.Errorf("package %s references source %s that is removed", settingString(r.Settings, "id"), name)
+			}
+			// The caller's resource is not modified
+			source = &WinGetResource{}
+			*source = *provided
+			source.Settings = maps.Clone(provided.Settings)
+			configured[strings.ToLower(name)] = source
+			injected = append(injected, source)
+		}
+
+		sourceID := source.ID
+		if sourceID == "" {
+			sourceID = "WinGetSource-" + settingString(source.Settings, "Name")
+		}
+
+		sourceIDs[source] = sourceID
+		dependencies[r] = sourceID
+	}
+
+	for source, sourceID := range sourceIDs {
+		source.ID = sourceID
+	}
+	for r, sourceID := range dependencies {
+		if !slices.Contains(r.DependsOn, sourceID) {
+			r.DependsOn = append(r.DependsOn, sourceID)
+		}
+	}
+	cfg.Properties.Resources = append(injected, cfg.Properties.Resources...)
+	return nil
+}
+
+func isDefaultSource(name string) bool {
+	for _, source := range DefaultSources {
+		if strings.EqualFold(source, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package wingetcfg
+
+import (
+	"slices"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestAddPackageSources(t *testing.T) {
+	source, err := AddSource("", "contoso", "https://winget.contoso.com/api", SourceTrustLevelTrusted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tool, err := InstallPackage("", "", "Contoso.Tool", "contoso", "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := NewWingetCfg()
+	cfg.AddResource(tool)
+	if err := cfg.AddPackageSources(source); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cfg.Properties.Resources) != 2 || cfg.Properties.Resources[0].Resource != WinGetSourceResource {
+		t.Fatal("the source should be added before the packages")
+	}
+	if cfg.Properties.Resources[0] == source || source.ID != "" {
+		t.Error("the caller's source should not be modified")
+	}
+	if cfg.Properties.Resources[0].ID != "WinGetSource-contoso" || !slices.Equal(tool.DependsOn, []string{"WinGetSource-contoso"}) {
+		t.Errorf("got source ID %q and dependencies %v", cfg.Properties.Resources[0].ID, tool.DependsOn)
+	}
+}
+
+func TestAddPackageSourcesKeepsDependencies(t *testing.T) {
+	source, err := AddSource("contoso-source", "contoso", "https://winget.contoso.com/api", SourceTrustLevelTrusted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runtime, err := InstallPackage("runtime", "", "Contoso.Runtime", "winget", "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tool, err := InstallPackage("tool", "", "Contoso.Tool", "contoso", "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tool.DependsOn = []string{"runtime"}
+
+	cfg := NewWingetCfg()
+	cfg.AddResource(runtime)
+	cfg.AddResource(tool)
+	if err := cfg.AddPackageSources(source); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(tool.DependsOn, []string{"runtime", "contoso-source"}) {
+		t.Fatalf("got dependencies %v", tool.DependsOn)
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	written := struct {
+		Properties struct {
+			Resources []struct {
+				ID        string    `yaml:"id"`
+				DependsOn yaml.Node `yaml:"dependsOn"`
+			} `yaml:"resources"`
+		} `yaml:"properties"`
+	}{}
+	if err := yaml.Unmarshal(data, &written); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range written.Properties.Resources {
+		if r.ID == "tool" && r.DependsOn.Kind != yaml.SequenceNode {
+			t.Errorf("dependsOn should be written as a YAML list:\n%s", data)
+		}
+	}
+}
+
+func TestAddPackageSourcesLeavesConfigUnchangedOnError(t *testing.T) {
+	source, err := AddSource("", "contoso", "https://winget.contoso.com/api", SourceTrustLevelTrusted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tool, err := InstallPackage("", "", "Contoso.Tool", "contoso", "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := InstallPackage("", "", "Fabrikam.Tool", "fabrikam", "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := NewWingetCfg()
+	cfg.AddResource(tool)
+	cfg.AddResource(other)
+	if err := cfg.AddPackageSources(source); err == nil {
+		t.Fatal("a package that references a source that has not been provided should fail")
+	}
+
+	if len(cfg.Properties.Resources) != 2 || tool.DependsOn != nil || source.ID != "" {
+		t.Error("the configuration and the sources should not be modified when there's an error")
+	}
+}
@@ -0,0 +1,204 @@
+package wingetcfg
+
+import (
+	"fmt"
+)
+
+const (
+	WinGetDeveloperModeResource         = "Microsoft.Windows.Developer/DeveloperMode"
+	WinGetWindowsExplorerResource       = "Microsoft.Windows.Developer/WindowsExplorer"
+	WinGetTaskbarResource               = "Microsoft.Windows.Developer/Taskbar"
+	WinGetEnableDarkModeResource        = "Microsoft.Windows.Developer/EnableDarkMode"
+	WinGetShowSecondsInClockResource    = "Microsoft.Windows.Developer/ShowSecondsInClock"
+	WinGetEnableLongPathSupportResource = "Microsoft.Windows.Developer/EnableLongPathSupport"
+)
+
+// KeepCurrentValue leaves a setting of the Microsoft.Windows.Developer resources unchanged,
+// it's the value used when a setting is empty
+const KeepCurrentValue = "KeepCurrentValue"
+
+// Visibility is used by the WindowsExplorer and Taskbar settings that show or hide an element
+type Visibility string
+
+const (
+	VisibilityKeepCurrentValue Visibility = KeepCurrentValue
+	VisibilityShow             Visibility = "Show"
+	VisibilityHide             Visibility = "Hide"
+)
+
+type TaskbarAlignment string
+
+const (
+	TaskbarAlignmentKeepCurrentValue TaskbarAlignment = KeepCurrentValue
+	TaskbarAlignmentLeft             TaskbarAlignment = "Left"
+	TaskbarAlignmentMiddle           TaskbarAlignment = "Middle"
+)
+
+type TaskbarSearchboxMode string
+
+const (
+	TaskbarSearchboxKeepCurrentValue TaskbarSearchboxMode = KeepCurrentValue
+	TaskbarSearchboxHide             TaskbarSearchboxMode = "Hide"
+	TaskbarSearchboxShowIconOnly     TaskbarSearchboxMode = "ShowIconOnly"
+	TaskbarSearchboxSearchBox        TaskbarSearchboxMode = "SearchBox"
+	TaskbarSearchboxShowIconAndLabel TaskbarSearchboxMode = "ShowIconAndLabel"
+)
+
+type TaskbarHideLabelsMode string
+
+const (
+	TaskbarHideLabelsKeepCurrentValue TaskbarHideLabelsMode = KeepCurrentValue
+	TaskbarHideLabelsWhenFull         TaskbarHideLabelsMode = "WhenFull"
+	TaskbarHideLabelsAlways           TaskbarHideLabelsMode = "Always"
+	TaskbarHideLabelsNever            TaskbarHideLabelsMode = "Never"
+)
+
+// EnableDeveloperMode turns on the developer mode, that allows installing apps from any source and
+// using developer features like symbolic links without elevation.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// Reference: https://github.com/microsoft/winget-dsc/blob/main/resources/Microsoft.Windows.Developer/Microsoft.Windows.Developer.psm1
+func EnableDeveloperMode(ID string, description string) (*WinGetResource, error) {
+	return NewDeveloperModeResource(ID, description, EnsurePresent)
+}
+
+// NewDeveloperModeResource creates a new WinGetResource that turns the developer mode on (Present) or off (Absent)
+func NewDeveloperModeResource(ID string, description string, ensure string) (*WinGetResource, error) {
+	return newEnsureDeveloperResource(WinGetDeveloperModeResource, ID, description, ensure, nil)
+}
+
+// NewWindowsExplorerResource creates a new WinGetResource that contains the File Explorer settings.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// FileExtensions shows or hides the extensions of known file types.
+// HiddenFiles shows or hides the hidden files and folders.
+// ItemCheckBoxes shows or hides the check boxes used to select items.
+// Empty settings keep their current value.
+// RestartExplorer restarts File Explorer so the changes are applied at once.
+func NewWindowsExplorerResource(ID string, description string, fileExtensions Visibility, hiddenFiles Visibility, itemCheckBoxes Visibility, restartExplorer bool) (*WinGetResource, error) {
+	r := newDeveloperResource(WinGetWindowsExplorerResource, ID, description)
+
+	for name, value := range map[string]Visibility{"FileExtensions": fileExtensions, "HiddenFiles": hiddenFiles, "ItemCheckBoxes": itemCheckBoxes} {
+		if err := setVisibility(r, name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if restartExplorer {
+		r.Settings["RestartExplorer"] = true
+	}
+
+	return r, nil
+}
+
+// NewTaskbarResource creates a new WinGetResource that contains the taskbar settings.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// Alignment places the taskbar icons on the left or in the middle.
+// HideLabelsMode sets when the labels of the taskbar buttons are hidden.
+// SearchboxMode sets how the search box is shown.
+// TaskViewButton shows or hides the task view button.
+// WidgetsButton shows or hides the widgets button.
+// Empty settings keep their current value.
+// RestartExplorer restarts File Explorer so the changes are applied at once.
+func NewTaskbarResource(ID string, description string, alignment TaskbarAlignment, hideLabelsMode TaskbarHideLabelsMode, searchboxMode TaskbarSearchboxMode, taskViewButton Visibility, widgetsButton Visibility, restartExplorer bool) (*WinGetResource, error) {
+	r := newDeveloperResource(WinGetTaskbarResource, ID, description)
+
+	switch alignment {
+	case "", TaskbarAlignmentKeepCurrentValue:
+	case TaskbarAlignmentLeft, TaskbarAlignmentMiddle:
+		r.Settings["Alignment"] = string(alignment)
+	default:
+		return nil, fmt.Errorf("taskbar alignment %s is not valid, use Left or Middle", alignment)
+	}
+
+	switch hideLabelsMode {
+	case "", TaskbarHideLabelsKeepCurrentValue:
+	case TaskbarHideLabelsWhenFull, TaskbarHideLabelsAlways, TaskbarHideLabelsNever:
+		r.Settings["HideLabelsMode"] = string(hideLabelsMode)
+	default:
+		return nil, fmt.Errorf("taskbar hide labels mode %s is not valid, use WhenFull, Always or Never", hideLabelsMode)
+	}
+
+	switch searchboxMode {
+	case "", TaskbarSearchboxKeepCurrentValue:
+	case TaskbarSearchboxHide, TaskbarSearchboxShowIconOnly, TaskbarSearchboxSearchBox, TaskbarSearchboxShowIconAndLabel:
+		r.Settings["SearchboxMode"] = string(searchboxMode)
+	default:
+		return nil, fmt.Errorf("taskbar searchbox mode %s is not valid, use Hide, ShowIconOnly, SearchBox or ShowIconAndLabel", searchboxMode)
+	}
+
+	if err := setVisibility(r, "TaskViewButton", taskViewButton); err != nil {
+		return nil, err
+	}
+
+	if err := setVisibility(r, "WidgetsButton", widgetsButton); err != nil {
+		return nil, err
+	}
+
+	if restartExplorer {
+		r.Settings["RestartExplorer"] = true
+	}
+
+	return r, nil
+}
+
+// NewEnableDarkModeResource creates a new WinGetResource that turns the dark theme on (Present) or off (Absent).
+// RestartExplorer restarts File Explorer so the change is applied at once.
+func NewEnableDarkModeResource(ID string, description string, ensure string, restartExplorer bool) (*WinGetResource, error) {
+	settings := map[string]any{}
+	if restartExplorer {
+		settings["RestartExplorer"] = true
+	}
+	return newEnsureDeveloperResource(WinGetEnableDarkModeResource, ID, description, ensure, settings)
+}
+
+// NewShowSecondsInClockResource creates a new WinGetResource that shows (Present) or hides (Absent) the seconds in the taskbar clock
+func NewShowSecondsInClockResource(ID string, description string, ensure string) (*WinGetResource, error) {
+	return newEnsureDeveloperResource(WinGetShowSecondsInClockResource, ID, description, ensure, nil)
+}
+
+// NewEnableLongPathSupportResource creates a new WinGetResource that allows (Present) or forbids (Absent)
+// paths longer than 260 characters in the applications that opt in
+func NewEnableLongPathSupportResource(ID string, description string, ensure string) (*WinGetResource, error) {
+	return newEnsureDeveloperResource(WinGetEnableLongPathSupportResource, ID, description, ensure, nil)
+}
+
+func newDeveloperResource(resource string, ID string, description string) *WinGetResource {
+	r := WinGetResource{}
+	r.Resource = resource
+
+	// ID (optional)
+	if ID != "" {
+		r.ID = ID
+	}
+
+	// Directives
+	r.Directives.Description = description
+	r.Directives.AllowPreRelease = true
+
+	// Settings
+	r.Settings = map[string]any{}
+
+	return &r
+}
+
+func newEnsureDeveloperResource(resource string, ID string, description string, ensure string, settings map[string]any) (*WinGetResource, error) {
+	r := newDeveloperResource(resource, ID, description)
+	for name, value := range settings {
+		r.Settings[name] = value
+	}
+	r.Settings["Ensure"] = SetEnsureValue(ensure)
+	return r, nil
+}
+
+func setVisibility(r *WinGetResource, name string, value Visibility) error {
+	switch value {
+	case "", VisibilityKeepCurrentValue:
+	case VisibilityShow, VisibilityHide:
+		r.Settings[name] = string(value)
+	default:
+		return fmt.Errorf("%s value %s is not valid, use Show or Hide", name, value)
+	}
+	return nil
+}
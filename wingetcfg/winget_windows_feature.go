@@ -0,0 +1,222 @@
+package wingetcfg
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+)
+
+const (
+	WinGetWindowsOptionalFeatureResource = "PSDscResources/WindowsOptionalFeature"
+	WinGetWindowsFeatureResource         = "xPSDesiredStateConfiguration/xWindowsFeature"
+	WinGetWindowsFeatureSetResource      = "xPSDesiredStateConfiguration/xWindowsFeatureSet"
+)
+
+// WindowsFeatureKind tells whether a feature is an optional feature of client SKUs, managed with DISM,
+// or a role or feature of Server SKUs, managed with Install-WindowsFeature
+type WindowsFeatureKind string
+
+const (
+	WindowsFeatureOptional WindowsFeatureKind = "optional"
+	WindowsFeatureServer   WindowsFeatureKind = "server"
+)
+
+// WindowsFeature is an entry of the bundled catalog of well-known feature names
+type WindowsFeature struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Category    string `json:"category"`
+}
+
+// Catalog of well-known features, one list per WindowsFeatureKind
+//
+//go:embed catalogs/windows_features.json
+var windowsFeaturesFile []byte
+
+var (
+	windowsFeatures     map[WindowsFeatureKind][]WindowsFeature
+	windowsFeaturesOnce sync.Once
+)
+
+// loadWindowsFeatures panics if the catalog is not valid, it's embedded so it can only fail with a broken build
+func loadWindowsFeatures() {
+	if err := json.Unmarshal(windowsFeaturesFile, &windowsFeatures); err != nil {
+		panic("wingetcfg: embedded catalog windows_features.json is not valid: " + err.Error())
+	}
+}
+
+// WindowsFeatures returns the well-known features of a kind, e.g. to offer them in a picker
+func WindowsFeatures(kind WindowsFeatureKind) []WindowsFeature {
+	windowsFeaturesOnce.Do(loadWindowsFeatures)
+	return append([]WindowsFeature{}, windowsFeatures[kind]...)
+}
+
+// LookupWindowsFeature finds a well-known feature by name, ignoring case as Windows does.
+// Features that are not in the catalog may still exist, e.g. in newer Windows versions.
+func LookupWindowsFeature(kind WindowsFeatureKind, name string) (WindowsFeature, bool) {
+	windowsFeaturesOnce.Do(loadWindowsFeatures)
+	for _, feature := range windowsFeatures[kind] {
+		if strings.EqualFold(feature.Name, name) {
+			return feature, true
+		}
+	}
+	return WindowsFeature{}, false
+}
+
+// EnableWindowsOptionalFeature enables an optional feature, e.g. Microsoft-Windows-Subsystem-Linux or NetFx3.
+// ID is an optional identifier.
+// Name is required and is the name of the feature as shown by Get-WindowsOptionalFeature.
+func EnableWindowsOptionalFeature(ID, name string) (*WinGetResource, error) {
+	return NewWindowsOptionalFeatureResource(ID, "", name, false, false, "", EnsurePresent)
+}
+
+// DisableWindowsOptionalFeature disables an optional feature, e.g. SMB1Protocol.
+// ID is an optional identifier.
+// Name is required and is the name of the feature as shown by Get-WindowsOptionalFeature.
+// RemoveFilesOnDisable removes the files of the feature from the disk.
+func DisableWindowsOptionalFeature(ID, name string, removeFilesOnDisable bool) (*WinGetResource, error) {
+	return NewWindowsOptionalFeatureResource(ID, "", name, removeFilesOnDisable, false, "", EnsureAbsent)
+}
+
+// NewWindowsOptionalFeatureResource creates a new WinGetResource that contains the settings to enable
+// or disable an optional feature of client SKUs.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// Name is required and is the name of the feature as shown by Get-WindowsOptionalFeature, the case of the
+// well-known names is fixed with the catalog.
+// RemoveFilesOnDisable removes the files of the feature from the disk when it's disabled.
+// NoWindowsUpdateCheck prevents DISM from contacting Windows Update to get the files of the feature.
+// LogPath is an optional path to the DISM log file.
+// Ensure specifies whether the feature should be enabled (Present) or disabled (Absent).
+// Reference: https://github.com/PowerShell/PSDscResources/blob/dev/DscResources/MSFT_WindowsOptionalFeature/MSFT_WindowsOptionalFeature.psm1
+func NewWindowsOptionalFeatureResource(ID, description string, name string, removeFilesOnDisable bool, noWindowsUpdateCheck bool, logPath string, ensure string) (*WinGetResource, error) {
+	r := WinGetResource{}
+	r.Resource = WinGetWindowsOptionalFeatureResource
+
+	// ID (optional)
+	if ID != "" {
+		r.ID = ID
+	}
+
+	// Directives
+	r.Directives.Description = description
+	r.Directives.AllowPreRelease = true
+
+	// Settings
+	r.Settings = map[string]any{}
+
+	if name == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+	if feature, ok := LookupWindowsFeature(WindowsFeatureOptional, name); ok {
+		name = feature.Name
+	}
+	r.Settings["Name"] = name
+
+	ensure = SetEnsureValue(ensure)
+	r.Settings["Ensure"] = ensure
+
+	if removeFilesOnDisable {
+		if ensure != EnsureAbsent {
+			return nil, errors.New("removeFilesOnDisable can only be used to disable a feature")
+		}
+		r.Settings["RemoveFilesOnDisable"] = true
+	}
+
+	r.Settings["NoWindowsUpdateCheck"] = noWindowsUpdateCheck
+
+	if logPath != "" {
+		r.Settings["LogPath"] = logPath
+	}
+
+	return &r, nil
+}
+
+// InstallWindowsFeature installs a role or feature of Server SKUs, e.g. Web-Server.
+// ID is an optional identifier.
+// Name is required and is the name of the feature as shown by Get-WindowsFeature.
+// IncludeAllSubFeature installs the sub features too.
+func InstallWindowsFeature(ID, name string, includeAllSubFeature bool) (*WinGetResource, error) {
+	return NewWindowsFeatureResource(ID, "", name, includeAllSubFeature, "", "", EnsurePresent)
+}
+
+// UninstallWindowsFeature uninstalls a role or feature of Server SKUs, e.g. FS-SMB1.
+// ID is an optional identifier.
+// Name is required and is the name of the feature as shown by Get-WindowsFeature.
+func UninstallWindowsFeature(ID, name string) (*WinGetResource, error) {
+	return NewWindowsFeatureResource(ID, "", name, false, "", "", EnsureAbsent)
+}
+
+// NewWindowsFeatureResource creates a new WinGetResource that contains the settings to install or
+// uninstall a role or feature of Server SKUs.
+// ID is an optional identifier.
+// Description is an optional text that describes the task to be performed.
+// Name is required and is the name of the feature as shown by Get-WindowsFeature, the case of the
+// well-known names is fixed with the catalog.
+// IncludeAllSubFeature installs or uninstalls the sub features too.
+// Source is an optional path to the installation files, e.g. the sources\sxs folder of the media.
+// LogPath is an optional path to the log file.
+// Ensure specifies whether the feature should be installed.
+// Reference: https://github.com/dsccommunity/xPSDesiredStateConfiguration/blob/main/source/DSCResources/DSC_xWindowsFeature/DSC_xWindowsFeature.psm1
+func NewWindowsFeatureResource(ID, description string, name string, includeAllSubFeature bool, source string, logPath string, ensure string) (*WinGetResource, error) {
+	if name == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+	if feature, ok := LookupWindowsFeature(WindowsFeatureServer, name); ok {
+		name = feature.Name
+	}
+	return newWindowsFeatureResource(WinGetWindowsFeatureResource, ID, description, name, includeAllSubFeature, source, logPath, ensure)
+}
+
+// NewWindowsFeatureSetResource creates a new WinGetResource that installs or uninstalls several roles
+// or features of Server SKUs at once, the arguments are the same as in NewWindowsFeatureResource.
+// Reference: https://github.com/dsccommunity/xPSDesiredStateConfiguration/blob/main/source/DSCResources/xWindowsFeatureSet/xWindowsFeatureSet.schema.psm1
+func NewWindowsFeatureSetResource(ID, description string, names []string, includeAllSubFeature bool, source string, logPath string, ensure string) (*WinGetResource, error) {
+	if len(names) == 0 {
+		return nil, errors.New("names cannot be empty")
+	}
+
+	features := []string{}
+	for _, name := range names {
+		if name == "" {
+			return nil, errors.New("names cannot have empty feature names")
+		}
+		if feature, ok := LookupWindowsFeature(WindowsFeatureServer, name); ok {
+			name = feature.Name
+		}
+		features = append(features, name)
+	}
+	return newWindowsFeatureResource(WinGetWindowsFeatureSetResource, ID, description, features, includeAllSubFeature, source, logPath, ensure)
+}
+
+func newWindowsFeatureResource(resource string, ID, description string, name any, includeAllSubFeature bool, source string, logPath string, ensure string) (*WinGetResource, error) {
+	r := WinGetResource{}
+	r.Resource = resource
+
+	// ID (optional)
+	if ID != "" {
+		r.ID = ID
+	}
+
+	// Directives
+	r.Directives.Description = description
+	r.Directives.AllowPreRelease = true
+
+	// Settings
+	r.Settings = map[string]any{}
+	r.Settings["Name"] = name
+	r.Settings["Ensure"] = SetEnsureValue(ensure)
+	r.Settings["IncludeAllSubFeature"] = includeAllSubFeature
+
+	if source != "" {
+		r.Settings["Source"] = source
+	}
+
+	if logPath != "" {
+		r.Settings["LogPath"] = logPath
+	}
+
+	return &r, nil
+}
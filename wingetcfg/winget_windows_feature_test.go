@@ -0,0 +1,21 @@
+package wingetcfg
+
+import "testing"
+
+func TestWindowsFeaturesCatalog(t *testing.T) {
+	for _, kind := range []WindowsFeatureKind{WindowsFeatureOptional, WindowsFeatureServer} {
+		features := WindowsFeatures(kind)
+		if len(features) == 0 {
+			t.Fatalf("the %s features catalog is empty", kind)
+		}
+		for _, feature := range features {
+			if feature.Name == "" {
+				t.Errorf("the %s features catalog has a feature without name", kind)
+			}
+		}
+	}
+
+	if _, ok := LookupWindowsFeature(WindowsFeatureOptional, "microsoft-windows-subsystem-linux"); !ok {
+		t.Error("Microsoft-Windows-Subsystem-Linux should be in the catalog")
+	}
+}
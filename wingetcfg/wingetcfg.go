@@ -1,6 +1,7 @@
 package wingetcfg
 
 import (
+	"errors"
 	"os"
 
 	"gopkg.in/yaml.v3"
@@ -20,9 +21,9 @@ type WinGetDirectives struct {
 }
 
 type WinGetResource struct {
-	Resource   string `yaml:"resource"`
-	ID         string `yaml:"id,omitempty"`
-	DependsOn  string `yaml:"dependsOn,omitempty"`
+	Resource   string   `yaml:"resource"`
+	ID         string   `yaml:"id,omitempty"`
+	DependsOn  []string `yaml:"dependsOn,omitempty"`
 	Directives WinGetDirectives
 	Settings   map[string]any
 }
@@ -79,6 +80,52 @@ func (cfg *WinGetCfg) WriteConfigFile(filePath string) error {
 	return nil
 }
 
+// ReadConfigFile reads a configuration file written with WriteConfigFile, see ParseConfig
+func ReadConfigFile(filePath string) (*WinGetCfg, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(data)
+}
+
+// ReadSignedConfigFile reads a configuration file written with WriteConfigFile, see ParseSignedConfig
+func ReadSignedConfigFile(filePath string, verifier ScriptVerifier) (*WinGetCfg, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSignedConfig(data, verifier)
+}
+
+// ParseConfig parses a configuration. The PowerShell scripts that carry a ScriptHash
+// are verified and an error is returned if any of them has been tampered.
+func ParseConfig(data []byte) (*WinGetCfg, error) {
+	return parseConfig(data, nil)
+}
+
+// ParseSignedConfig parses a configuration whose PowerShell scripts must be signed. An error is
+// returned if any of them has no hash, no signature or a signature that the verifier rejects.
+func ParseSignedConfig(data []byte, verifier ScriptVerifier) (*WinGetCfg, error) {
+	if verifier == nil {
+		return nil, errors.New("verifier cannot be nil")
+	}
+	return parseConfig(data, verifier)
+}
+
+func parseConfig(data []byte, verifier ScriptVerifier) (*WinGetCfg, error) {
+	cfg := WinGetCfg{}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.verifyScriptHashes(verifier); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
 func SetEnsureValue(ensure string) string {
 	switch ensure {
 	case EnsurePresent, EnsureAbsent: